@@ -0,0 +1,115 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type AccountClient struct {
+	id   int
+	conn connection
+}
+
+func (a AccountClient) Memberships() ([]AccountMembership, error) {
+	a.conn = a.conn.named("tracker.AccountMemberships")
+	request, err := a.createRequest("GET", "/memberships", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberships []AccountMembership
+	_, err = a.conn.Do(request, &memberships)
+	if err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+func (a AccountClient) AddMember(membership NewAccountMembership) (AccountMembership, error) {
+	a.conn = a.conn.named("tracker.AddAccountMember")
+	request, err := a.createRequest("POST", "/memberships", nil)
+	if err != nil {
+		return AccountMembership{}, err
+	}
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(membership)
+
+	a.conn.AddJSONBodyReader(request, buffer)
+
+	var createdMembership AccountMembership
+	_, err = a.conn.Do(request, &createdMembership)
+	return createdMembership, err
+}
+
+func (a AccountClient) UpdateMember(personID int, permissions AccountPermissions) (AccountMembership, error) {
+	a.conn = a.conn.named("tracker.UpdateAccountMember")
+	url := fmt.Sprintf("/memberships/%d", personID)
+	request, err := a.createRequest("PUT", url, nil)
+	if err != nil {
+		return AccountMembership{}, err
+	}
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(permissions)
+
+	a.conn.AddJSONBodyReader(request, buffer)
+
+	var updatedMembership AccountMembership
+	_, err = a.conn.Do(request, &updatedMembership)
+	return updatedMembership, err
+}
+
+func (a AccountClient) RemoveMember(personID int) error {
+	a.conn = a.conn.named("tracker.RemoveAccountMember")
+	url := fmt.Sprintf("/memberships/%d", personID)
+	request, err := a.createRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.conn.Do(request, nil)
+	return err
+}
+
+func (a AccountClient) Projects() ([]Project, error) {
+	a.conn = a.conn.named("tracker.AccountProjects")
+	params := url.Values{}
+	params.Set("account_ids", strconv.Itoa(a.id))
+
+	request, err := a.conn.CreateRequest("GET", "/projects", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []Project
+	_, err = a.conn.Do(request, &projects)
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (a AccountClient) createRequest(method string, path string, params url.Values) (*http.Request, error) {
+	accountPath := fmt.Sprintf("/accounts/%d%s", a.id, path)
+	return a.conn.CreateRequest(method, accountPath, params)
+}
@@ -0,0 +1,72 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import "github.com/deoxxa/go-tracker"
+
+// TransitionsFromActivity extracts the story state changes recorded in a
+// project's or story's activity feed, for use when the transitions endpoint
+// is not available.
+func TransitionsFromActivity(activities []tracker.Activity) []tracker.StoryTransition {
+	var transitions []tracker.StoryTransition
+
+	for _, activity := range activities {
+		projectID := 0
+		if project, ok := activity.Project.(map[string]interface{}); ok {
+			projectID = intValue(project["id"])
+		}
+
+		performedByID := 0
+		if person, ok := activity.PerformedBy.(map[string]interface{}); ok {
+			performedByID = intValue(person["id"])
+		}
+
+		for _, change := range activity.Changes {
+			values, ok := change.(map[string]interface{})
+			if !ok || values["kind"] != "story" {
+				continue
+			}
+
+			newValues, ok := values["new_values"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			state, ok := newValues["current_state"].(string)
+			if !ok {
+				continue
+			}
+
+			transitions = append(transitions, tracker.StoryTransition{
+				Kind:           "story_transition",
+				State:          tracker.StoryState(state),
+				StoryID:        intValue(values["id"]),
+				ProjectID:      projectID,
+				ProjectVersion: activity.ProjectVersion,
+				OccurredAt:     activity.OccurredAt,
+				PerformedByID:  performedByID,
+			})
+		}
+	}
+
+	return transitions
+}
+
+func intValue(value interface{}) int {
+	if number, ok := value.(float64); ok {
+		return int(number)
+	}
+	return 0
+}
@@ -0,0 +1,244 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package analytics computes flow metrics such as lead time, cycle time and
+// time spent in each state from Tracker stories and their transitions.
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+// StoryMetrics holds the flow metrics of a single story. LeadTime and
+// CycleTime are zero for stories which have not been accepted, or whose
+// creation or start is unknown, and are then left out of summaries.
+type StoryMetrics struct {
+	StoryID     int
+	Type        tracker.StoryType
+	Accepted    bool
+	Delivered   bool
+	Rejections  int
+	LeadTime    time.Duration
+	CycleTime   time.Duration
+	TimeInState map[tracker.StoryState]time.Duration
+}
+
+// Measure computes the metrics of a story from its transitions. Lead time
+// runs from creation to acceptance and cycle time from the first time the
+// story was started to acceptance.
+func Measure(story tracker.Story, transitions []tracker.StoryTransition) StoryMetrics {
+	metrics := StoryMetrics{
+		StoryID:     story.ID,
+		Type:        story.Type,
+		TimeInState: map[tracker.StoryState]time.Duration{},
+	}
+
+	sorted := make([]tracker.StoryTransition, len(transitions))
+	copy(sorted, transitions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OccurredAt.Before(sorted[j].OccurredAt)
+	})
+
+	var startedAt, acceptedAt time.Time
+	for i, transition := range sorted {
+		switch transition.State {
+		case tracker.StoryStateStarted:
+			if startedAt.IsZero() {
+				startedAt = transition.OccurredAt
+			}
+		case tracker.StoryStateDelivered:
+			metrics.Delivered = true
+		case tracker.StoryStateRejected:
+			metrics.Rejections++
+		case tracker.StoryStateAccepted:
+			acceptedAt = transition.OccurredAt
+		}
+
+		if i+1 < len(sorted) {
+			metrics.TimeInState[transition.State] += sorted[i+1].OccurredAt.Sub(transition.OccurredAt)
+		}
+	}
+
+	if story.AcceptedAt != nil {
+		acceptedAt = *story.AcceptedAt
+	}
+
+	if acceptedAt.IsZero() {
+		return metrics
+	}
+
+	metrics.Accepted = true
+	if story.CreatedAt != nil {
+		metrics.LeadTime = acceptedAt.Sub(*story.CreatedAt)
+	}
+	if !startedAt.IsZero() {
+		metrics.CycleTime = acceptedAt.Sub(startedAt)
+	}
+
+	return metrics
+}
+
+// Stats summarises a set of durations.
+type Stats struct {
+	Count int      `json:"count"`
+	Mean  Duration `json:"mean_hours"`
+	P50   Duration `json:"p50_hours"`
+	P85   Duration `json:"p85_hours"`
+	P95   Duration `json:"p95_hours"`
+}
+
+// NewStats computes the mean and nearest-rank percentiles of durations.
+func NewStats(durations []time.Duration) Stats {
+	if len(durations) == 0 {
+		return Stats{}
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, duration := range sorted {
+		total += duration
+	}
+
+	return Stats{
+		Count: len(sorted),
+		Mean:  Duration(total / time.Duration(len(sorted))),
+		P50:   Duration(Percentile(sorted, 50)),
+		P85:   Duration(Percentile(sorted, 85)),
+		P95:   Duration(Percentile(sorted, 95)),
+	}
+}
+
+// Percentile returns the nearest-rank percentile of an ascending list of
+// durations.
+func Percentile(sorted []time.Duration, percentile float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	return sorted[nearestRank(len(sorted), percentile)]
+}
+
+// nearestRank returns the index of the percentile in an ascending list of n
+// values.
+func nearestRank(n int, percentile float64) int {
+	rank := int(math.Ceil(percentile / 100 * float64(n)))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > n {
+		rank = n
+	}
+
+	return rank - 1
+}
+
+// Summary aggregates the metrics of a group of stories.
+type Summary struct {
+	Group         string                       `json:"group"`
+	Stories       int                          `json:"stories"`
+	Accepted      int                          `json:"accepted"`
+	RejectionRate float64                      `json:"rejection_rate"`
+	LeadTime      Stats                        `json:"lead_time"`
+	CycleTime     Stats                        `json:"cycle_time"`
+	TimeInState   map[tracker.StoryState]Stats `json:"time_in_state"`
+}
+
+// Analyze measures every story and summarises the results for each group
+// returned by group. Transitions are matched to stories by StoryID. A nil
+// group puts every story in a single group called "all".
+func Analyze(stories []tracker.Story, transitions []tracker.StoryTransition, group GroupFunc) []Summary {
+	if group == nil {
+		group = All
+	}
+
+	byStory := map[int][]tracker.StoryTransition{}
+	for _, transition := range transitions {
+		byStory[transition.StoryID] = append(byStory[transition.StoryID], transition)
+	}
+
+	var groups []string
+	grouped := map[string][]StoryMetrics{}
+	for _, story := range stories {
+		metrics := Measure(story, byStory[story.ID])
+		for _, name := range group(story) {
+			if _, ok := grouped[name]; !ok {
+				groups = append(groups, name)
+			}
+			grouped[name] = append(grouped[name], metrics)
+		}
+	}
+
+	sort.Strings(groups)
+
+	summaries := make([]Summary, 0, len(groups))
+	for _, name := range groups {
+		summaries = append(summaries, summarize(name, grouped[name]))
+	}
+
+	return summaries
+}
+
+func summarize(group string, stories []StoryMetrics) Summary {
+	summary := Summary{
+		Group:       group,
+		Stories:     len(stories),
+		TimeInState: map[tracker.StoryState]Stats{},
+	}
+
+	var leadTimes, cycleTimes []time.Duration
+	var delivered, rejected int
+	timeInState := map[tracker.StoryState][]time.Duration{}
+
+	for _, metrics := range stories {
+		if metrics.Accepted {
+			summary.Accepted++
+			if metrics.LeadTime != 0 {
+				leadTimes = append(leadTimes, metrics.LeadTime)
+			}
+			if metrics.CycleTime != 0 {
+				cycleTimes = append(cycleTimes, metrics.CycleTime)
+			}
+		}
+
+		if metrics.Delivered {
+			delivered++
+			if metrics.Rejections > 0 {
+				rejected++
+			}
+		}
+
+		for state, duration := range metrics.TimeInState {
+			timeInState[state] = append(timeInState[state], duration)
+		}
+	}
+
+	summary.LeadTime = NewStats(leadTimes)
+	summary.CycleTime = NewStats(cycleTimes)
+	for state, durations := range timeInState {
+		summary.TimeInState[state] = NewStats(durations)
+	}
+
+	if delivered > 0 {
+		summary.RejectionRate = float64(rejected) / float64(delivered)
+	}
+
+	return summary
+}
@@ -0,0 +1,38 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package analytics_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnalytics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Analytics Suite")
+}
+
+func Fixture(filename string) string {
+	path := filepath.Join("..", "fixtures", filename)
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(contents)
+}
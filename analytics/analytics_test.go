@@ -0,0 +1,228 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package analytics_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+	"github.com/deoxxa/go-tracker/analytics"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Analytics", func() {
+	var (
+		day       = 24 * time.Hour
+		start     = time.Date(2015, 7, 20, 0, 0, 0, 0, time.UTC)
+		at        = func(days int) time.Time { return start.Add(time.Duration(days) * day) }
+		atPointer = func(days int) *time.Time { t := at(days); return &t }
+
+		stories     []tracker.Story
+		transitions []tracker.StoryTransition
+	)
+
+	transition := func(storyID int, state tracker.StoryState, days int) tracker.StoryTransition {
+		return tracker.StoryTransition{StoryID: storyID, State: state, OccurredAt: at(days)}
+	}
+
+	BeforeEach(func() {
+		stories = []tracker.Story{
+			{
+				ID:         1,
+				Type:       tracker.StoryTypeFeature,
+				Labels:     []tracker.Label{{Name: "deflector shield"}},
+				OwnerIDs:   []int{101},
+				CreatedAt:  atPointer(0),
+				AcceptedAt: atPointer(6),
+			},
+			{
+				ID:         2,
+				Type:       tracker.StoryTypeBug,
+				Labels:     []tracker.Label{{Name: "deflector shield"}, {Name: "tractor beam"}},
+				OwnerIDs:   []int{101, 102},
+				CreatedAt:  atPointer(1),
+				AcceptedAt: atPointer(9),
+			},
+			{
+				ID:        3,
+				Type:      tracker.StoryTypeFeature,
+				CreatedAt: atPointer(2),
+			},
+		}
+
+		transitions = []tracker.StoryTransition{
+			transition(1, tracker.StoryStateAccepted, 6),
+			transition(1, tracker.StoryStateStarted, 2),
+			transition(1, tracker.StoryStateFinished, 4),
+			transition(1, tracker.StoryStateDelivered, 5),
+
+			transition(2, tracker.StoryStateStarted, 3),
+			transition(2, tracker.StoryStateFinished, 4),
+			transition(2, tracker.StoryStateDelivered, 5),
+			transition(2, tracker.StoryStateRejected, 6),
+			transition(2, tracker.StoryStateStarted, 7),
+			transition(2, tracker.StoryStateFinished, 8),
+			transition(2, tracker.StoryStateDelivered, 8),
+			transition(2, tracker.StoryStateAccepted, 9),
+
+			transition(3, tracker.StoryStateStarted, 3),
+		}
+	})
+
+	Describe("measuring a story", func() {
+		It("computes lead time, cycle time and time in each state", func() {
+			metrics := analytics.Measure(stories[1], transitions[4:12])
+
+			Expect(metrics.Accepted).To(BeTrue())
+			Expect(metrics.LeadTime).To(Equal(8 * day))
+			Expect(metrics.CycleTime).To(Equal(6 * day))
+			Expect(metrics.Rejections).To(Equal(1))
+			Expect(metrics.TimeInState[tracker.StoryStateStarted]).To(Equal(2 * day))
+			Expect(metrics.TimeInState[tracker.StoryStateFinished]).To(Equal(1 * day))
+			Expect(metrics.TimeInState[tracker.StoryStateDelivered]).To(Equal(2 * day))
+		})
+
+		It("leaves lead and cycle time empty for unaccepted stories", func() {
+			metrics := analytics.Measure(stories[2], transitions[12:])
+
+			Expect(metrics.Accepted).To(BeFalse())
+			Expect(metrics.LeadTime).To(BeZero())
+			Expect(metrics.CycleTime).To(BeZero())
+		})
+	})
+
+	Describe("percentiles", func() {
+		It("uses the nearest rank", func() {
+			var durations []time.Duration
+			for i := 1; i <= 20; i++ {
+				durations = append(durations, time.Duration(i)*time.Hour)
+			}
+
+			stats := analytics.NewStats(durations)
+			Expect(stats.Count).To(Equal(20))
+			Expect(time.Duration(stats.P50)).To(Equal(10 * time.Hour))
+			Expect(time.Duration(stats.P85)).To(Equal(17 * time.Hour))
+			Expect(time.Duration(stats.P95)).To(Equal(19 * time.Hour))
+			Expect(stats.Mean.Hours()).To(Equal(10.5))
+		})
+	})
+
+	Describe("summarising groups of stories", func() {
+		It("puts every story in one group by default", func() {
+			summaries := analytics.Analyze(stories, transitions, nil)
+
+			Expect(summaries).To(HaveLen(1))
+			Expect(summaries[0].Group).To(Equal("all"))
+			Expect(summaries[0].Stories).To(Equal(3))
+			Expect(summaries[0].Accepted).To(Equal(2))
+			Expect(summaries[0].RejectionRate).To(Equal(0.5))
+			Expect(time.Duration(summaries[0].LeadTime.P95)).To(Equal(8 * day))
+			Expect(time.Duration(summaries[0].CycleTime.P50)).To(Equal(4 * day))
+		})
+
+		It("leaves stories without a creation time out of lead time", func() {
+			stories = append(stories, tracker.Story{ID: 4, Type: tracker.StoryTypeChore, AcceptedAt: atPointer(9)})
+
+			summaries := analytics.Analyze(stories, transitions, nil)
+
+			Expect(summaries[0].Accepted).To(Equal(3))
+			Expect(summaries[0].LeadTime.Count).To(Equal(2))
+			Expect(time.Duration(summaries[0].LeadTime.Mean)).To(Equal(7 * day))
+		})
+
+		It("groups by story type", func() {
+			summaries := analytics.Analyze(stories, transitions, analytics.ByStoryType)
+
+			Expect(summaries).To(HaveLen(2))
+			Expect(summaries[0].Group).To(Equal("bug"))
+			Expect(summaries[1].Group).To(Equal("feature"))
+			Expect(summaries[1].Stories).To(Equal(2))
+		})
+
+		It("groups by label, counting stories under every label they have", func() {
+			summaries := analytics.Analyze(stories, transitions, analytics.ByLabel)
+
+			Expect(summaries).To(HaveLen(2))
+			Expect(summaries[0].Group).To(Equal("deflector shield"))
+			Expect(summaries[0].Stories).To(Equal(2))
+			Expect(summaries[1].Group).To(Equal("tractor beam"))
+			Expect(summaries[1].Stories).To(Equal(1))
+		})
+
+		It("groups by owner", func() {
+			names := map[int]string{101: "vader", 102: "tarkin"}
+			summaries := analytics.Analyze(stories, transitions, analytics.ByOwner(func(id int) string {
+				return names[id]
+			}))
+
+			Expect(summaries).To(HaveLen(2))
+			Expect(summaries[0].Group).To(Equal("tarkin"))
+			Expect(summaries[1].Group).To(Equal("vader"))
+			Expect(summaries[1].Stories).To(Equal(2))
+		})
+
+		It("groups by the iteration stories were accepted in", func() {
+			summaries := analytics.Analyze(stories, transitions, analytics.ByIteration(start, 7*day))
+
+			Expect(summaries).To(HaveLen(2))
+			Expect(summaries[0].Group).To(Equal("1"))
+			Expect(summaries[1].Group).To(Equal("2"))
+		})
+	})
+
+	Describe("serialising summaries", func() {
+		It("writes durations to JSON in hours", func() {
+			summaries := analytics.Analyze(stories, transitions, nil)
+
+			encoded, err := json.Marshal(summaries[0].LeadTime)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded).To(MatchJSON(`{"count":2,"mean_hours":168,"p50_hours":144,"p85_hours":192,"p95_hours":192}`))
+
+			var decoded analytics.Stats
+			Expect(json.Unmarshal(encoded, &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(summaries[0].LeadTime))
+		})
+
+		It("writes a CSV row per group", func() {
+			summaries := analytics.Analyze(stories, transitions, analytics.ByStoryType)
+
+			buffer := &bytes.Buffer{}
+			Expect(analytics.WriteCSV(buffer, summaries)).To(Succeed())
+
+			lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(HavePrefix("group,stories,accepted,rejection_rate,lead_time_mean_hours"))
+			Expect(lines[1]).To(HavePrefix("bug,1,1,1,192,192,192,192,144,"))
+		})
+	})
+
+	Describe("reading transitions from activity", func() {
+		It("finds story state changes", func() {
+			var activities []tracker.Activity
+			Expect(json.Unmarshal([]byte(Fixture("activities.json")), &activities)).To(Succeed())
+
+			transitions := analytics.TransitionsFromActivity(activities)
+			Expect(transitions).To(HaveLen(2))
+			Expect(transitions[0].StoryID).To(Equal(556))
+			Expect(transitions[0].State).To(Equal(tracker.StoryStateStarted))
+			Expect(transitions[0].ProjectVersion).To(Equal(45))
+			Expect(transitions[0].PerformedByID).To(Equal(101))
+			Expect(transitions[1].State).To(Equal(tracker.StoryStateUnscheduled))
+		})
+	})
+})
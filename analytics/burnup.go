@@ -0,0 +1,128 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+type BurnupPoint struct {
+	At        time.Time `json:"at"`
+	Scope     int       `json:"scope"`
+	Accepted  int       `json:"accepted"`
+	Remaining int       `json:"remaining"`
+}
+
+// Burnup samples the total and accepted points of the stories every step
+// from from until to. Remaining gives the matching burndown. Stories count
+// towards the scope from when they were created.
+func Burnup(stories []tracker.Story, from time.Time, to time.Time, step time.Duration) []BurnupPoint {
+	if step <= 0 {
+		return nil
+	}
+
+	var points []BurnupPoint
+	for at := from; !at.After(to); at = at.Add(step) {
+		point := BurnupPoint{At: at}
+
+		for _, story := range stories {
+			if story.CreatedAt != nil && story.CreatedAt.After(at) {
+				continue
+			}
+
+			point.Scope += story.Points()
+			if story.AcceptedAt != nil && !story.AcceptedAt.After(at) {
+				point.Accepted += story.Points()
+			}
+		}
+
+		point.Remaining = point.Scope - point.Accepted
+		points = append(points, point)
+	}
+
+	return points
+}
+
+type Forecast struct {
+	RemainingPoints int       `json:"remaining_points"`
+	Velocity        float64   `json:"velocity"`
+	Iterations      int       `json:"iterations"`
+	Completion      time.Time `json:"completion"`
+}
+
+// ForecastCompletion estimates when the unaccepted stories will be done if
+// the team keeps its velocity, counting whole iterations from from.
+func ForecastCompletion(stories []tracker.Story, velocity float64, from time.Time, iterationLength time.Duration) (Forecast, error) {
+	forecast := Forecast{
+		RemainingPoints: RemainingPoints(stories),
+		Velocity:        velocity,
+		Completion:      from,
+	}
+
+	if forecast.RemainingPoints == 0 {
+		return forecast, nil
+	}
+
+	if velocity <= 0 {
+		return forecast, errors.New("cannot forecast completion without a positive velocity")
+	}
+
+	forecast.Iterations = int(math.Ceil(float64(forecast.RemainingPoints) / velocity))
+	forecast.Completion = from.Add(time.Duration(forecast.Iterations) * iterationLength)
+
+	return forecast, nil
+}
+
+// RemainingPoints sums the estimates of the stories which have not been
+// accepted.
+func RemainingPoints(stories []tracker.Story) int {
+	points := 0
+	for _, story := range stories {
+		if story.State != tracker.StoryStateAccepted && story.AcceptedAt == nil {
+			points += story.Points()
+		}
+	}
+	return points
+}
+
+// WithLabel returns the stories which have the label.
+func WithLabel(stories []tracker.Story, label string) []tracker.Story {
+	var labelled []tracker.Story
+	for _, story := range stories {
+		for _, storyLabel := range story.Labels {
+			if storyLabel.Name == label {
+				labelled = append(labelled, story)
+				break
+			}
+		}
+	}
+	return labelled
+}
+
+// UpToRelease returns the stories which come before the release in the
+// given backlog order, along with the release itself. All stories are
+// returned if the release is not found.
+func UpToRelease(stories []tracker.Story, releaseID int) []tracker.Story {
+	for i, story := range stories {
+		if story.ID == releaseID {
+			return stories[:i+1]
+		}
+	}
+	return stories
+}
@@ -0,0 +1,73 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+// GroupFunc returns the names of the groups a story belongs to. A story may
+// belong to several groups, or to none in which case it is left out.
+type GroupFunc func(story tracker.Story) []string
+
+func All(story tracker.Story) []string {
+	return []string{"all"}
+}
+
+func ByStoryType(story tracker.Story) []string {
+	return []string{string(story.Type)}
+}
+
+func ByLabel(story tracker.Story) []string {
+	names := make([]string, 0, len(story.Labels))
+	for _, label := range story.Labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+// ByOwner groups stories by the IDs of their owners. The names function
+// turns an owner ID into a group name; when nil the ID itself is used.
+func ByOwner(names func(ownerID int) string) GroupFunc {
+	if names == nil {
+		names = strconv.Itoa
+	}
+
+	return func(story tracker.Story) []string {
+		owners := make([]string, 0, len(story.OwnerIDs))
+		for _, ownerID := range story.OwnerIDs {
+			owners = append(owners, names(ownerID))
+		}
+		return owners
+	}
+}
+
+// ByIteration groups accepted stories by the number of the iteration they
+// were accepted in, counting from the iteration starting at start.
+// Unaccepted stories are left out.
+func ByIteration(start time.Time, iterationLength time.Duration) GroupFunc {
+	return func(story tracker.Story) []string {
+		if story.AcceptedAt == nil || story.AcceptedAt.Before(start) || iterationLength <= 0 {
+			return nil
+		}
+
+		number := int(story.AcceptedAt.Sub(start)/iterationLength) + 1
+		return []string{fmt.Sprintf("%d", number)}
+	}
+}
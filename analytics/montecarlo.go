@@ -0,0 +1,125 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+const (
+	defaultTrials = 10000
+
+	// maxSimulatedIterations stops a trial which keeps drawing quiet
+	// iterations from running forever.
+	maxSimulatedIterations = 1000
+)
+
+type SimulationOptions struct {
+	// Trials is the number of simulated futures to run; it defaults to
+	// 10000.
+	Trials int
+
+	// Seed seeds the random number generator so that simulations can be
+	// repeated.
+	Seed int64
+}
+
+type Projection struct {
+	Iterations int       `json:"iterations"`
+	Completion time.Time `json:"completion"`
+}
+
+type SimulatedForecast struct {
+	RemainingPoints int        `json:"remaining_points"`
+	Trials          int        `json:"trials"`
+	P50             Projection `json:"p50"`
+	P85             Projection `json:"p85"`
+	P95             Projection `json:"p95"`
+}
+
+// PointsPerIteration returns the points accepted in each iteration, for
+// use as the history of a simulation.
+func PointsPerIteration(iterations []tracker.Iteration, stories []tracker.Story) []int {
+	points := make([]int, 0, len(iterations))
+	for _, iteration := range iterations {
+		points = append(points, AcceptedPoints(iteration, stories))
+	}
+	return points
+}
+
+// SimulateCompletion forecasts when the unaccepted stories will be done by
+// replaying randomly chosen iterations from the history of accepted points
+// until the remaining points are used up, many times over. The completion
+// date that 50%, 85% and 95% of the trials finished by is reported,
+// counting whole iterations from from.
+func SimulateCompletion(history []int, stories []tracker.Story, from time.Time, iterationLength time.Duration, options SimulationOptions) (SimulatedForecast, error) {
+	trials := options.Trials
+	if trials <= 0 {
+		trials = defaultTrials
+	}
+
+	forecast := SimulatedForecast{
+		RemainingPoints: RemainingPoints(stories),
+		Trials:          trials,
+	}
+
+	if forecast.RemainingPoints > 0 {
+		productive := false
+		for _, points := range history {
+			if points > 0 {
+				productive = true
+				break
+			}
+		}
+
+		if !productive {
+			return forecast, errors.New("cannot simulate completion without a history of accepted points")
+		}
+	}
+
+	random := rand.New(rand.NewSource(options.Seed))
+
+	results := make([]int, trials)
+	for trial := range results {
+		remaining := forecast.RemainingPoints
+		iterations := 0
+		for remaining > 0 && iterations < maxSimulatedIterations {
+			remaining -= history[random.Intn(len(history))]
+			iterations++
+		}
+		results[trial] = iterations
+	}
+
+	sort.Ints(results)
+
+	project := func(percentile float64) Projection {
+		iterations := results[nearestRank(len(results), percentile)]
+		return Projection{
+			Iterations: iterations,
+			Completion: from.Add(time.Duration(iterations) * iterationLength),
+		}
+	}
+
+	forecast.P50 = project(50)
+	forecast.P85 = project(85)
+	forecast.P95 = project(95)
+
+	return forecast, nil
+}
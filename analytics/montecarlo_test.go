@@ -0,0 +1,80 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package analytics_test
+
+import (
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+	"github.com/deoxxa/go-tracker/analytics"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Monte Carlo forecasting", func() {
+	var (
+		week    = 7 * 24 * time.Hour
+		start   = time.Date(2015, 7, 6, 7, 0, 0, 0, time.UTC)
+		backlog = []tracker.Story{
+			{Estimate: tracker.Estimate(8)},
+			{Estimate: tracker.Estimate(5)},
+			{Estimate: tracker.Estimate(8)},
+			{Estimate: tracker.Estimate(3), State: tracker.StoryStateAccepted},
+			{Estimate: tracker.Estimate(9)},
+		}
+	)
+
+	It("is repeatable for the same seed", func() {
+		history := []int{4, 10, 6, 8, 2, 12}
+		options := analytics.SimulationOptions{Trials: 500, Seed: 42}
+
+		first, err := analytics.SimulateCompletion(history, backlog, start, week, options)
+		Expect(err).NotTo(HaveOccurred())
+
+		second, err := analytics.SimulateCompletion(history, backlog, start, week, options)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(first).To(Equal(second))
+		Expect(first.RemainingPoints).To(Equal(30))
+		Expect(first.Trials).To(Equal(500))
+		Expect(first.P50.Iterations).To(BeNumerically("<=", first.P85.Iterations))
+		Expect(first.P85.Iterations).To(BeNumerically("<=", first.P95.Iterations))
+		Expect(first.P95.Completion).To(Equal(start.Add(time.Duration(first.P95.Iterations) * week)))
+	})
+
+	It("is exact when every iteration is the same", func() {
+		forecast, err := analytics.SimulateCompletion([]int{10}, backlog, start, week, analytics.SimulationOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(forecast.Trials).To(Equal(10000))
+		Expect(forecast.P50).To(Equal(analytics.Projection{Iterations: 3, Completion: start.Add(3 * week)}))
+		Expect(forecast.P95).To(Equal(forecast.P50))
+	})
+
+	It("needs some accepted points in its history", func() {
+		_, err := analytics.SimulateCompletion([]int{0, 0}, backlog, start, week, analytics.SimulationOptions{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("builds the history from iterations", func() {
+		acceptedAt := start.Add(time.Hour)
+		iterations := []tracker.Iteration{
+			{Start: start, Finish: start.Add(week)},
+			{Start: start.Add(week), Finish: start.Add(2 * week)},
+		}
+
+		history := analytics.PointsPerIteration(iterations, []tracker.Story{{Estimate: tracker.Estimate(5), AcceptedAt: &acceptedAt}})
+		Expect(history).To(Equal([]int{5, 0}))
+	})
+})
@@ -0,0 +1,109 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+// Duration is a time.Duration which is written to JSON as a number of
+// hours, the unit most reports work in.
+type Duration time.Duration
+
+func (d Duration) Hours() float64 {
+	return time.Duration(d).Hours()
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Hours())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var hours float64
+	if err := json.Unmarshal(data, &hours); err != nil {
+		return err
+	}
+
+	*d = Duration(hours * float64(time.Hour))
+	return nil
+}
+
+// WriteCSV writes one row per summary. Durations are written in hours and
+// time in state is given as the mean for each story state.
+func WriteCSV(w io.Writer, summaries []Summary) error {
+	header := []string{
+		"group",
+		"stories",
+		"accepted",
+		"rejection_rate",
+		"lead_time_mean_hours",
+		"lead_time_p50_hours",
+		"lead_time_p85_hours",
+		"lead_time_p95_hours",
+		"cycle_time_mean_hours",
+		"cycle_time_p50_hours",
+		"cycle_time_p85_hours",
+		"cycle_time_p95_hours",
+	}
+	for _, state := range tracker.AllStoryStates() {
+		header = append(header, "time_in_"+string(state)+"_mean_hours")
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, summary := range summaries {
+		row := []string{
+			summary.Group,
+			strconv.Itoa(summary.Stories),
+			strconv.Itoa(summary.Accepted),
+			formatFloat(summary.RejectionRate),
+			formatHours(summary.LeadTime.Mean),
+			formatHours(summary.LeadTime.P50),
+			formatHours(summary.LeadTime.P85),
+			formatHours(summary.LeadTime.P95),
+			formatHours(summary.CycleTime.Mean),
+			formatHours(summary.CycleTime.P50),
+			formatHours(summary.CycleTime.P85),
+			formatHours(summary.CycleTime.P95),
+		}
+		for _, state := range tracker.AllStoryStates() {
+			row = append(row, formatHours(summary.TimeInState[state].Mean))
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatHours(d Duration) string {
+	return formatFloat(d.Hours())
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+// defaultVelocityAveragedOver is used when a project does not say how many
+// iterations its velocity is averaged over.
+const defaultVelocityAveragedOver = 3
+
+type IterationVelocity struct {
+	Number   int       `json:"number"`
+	Start    time.Time `json:"start"`
+	Finish   time.Time `json:"finish"`
+	Points   int       `json:"points"`
+	Velocity float64   `json:"velocity"`
+}
+
+// IterationLength returns the length of the project's iterations.
+func IterationLength(project tracker.Project) time.Duration {
+	return time.Duration(project.IterationLength) * 7 * 24 * time.Hour
+}
+
+// AcceptedPoints sums the estimates of the stories accepted during the
+// iteration.
+func AcceptedPoints(iteration tracker.Iteration, stories []tracker.Story) int {
+	points := 0
+	for _, story := range stories {
+		if story.AcceptedAt == nil {
+			continue
+		}
+
+		if !story.AcceptedAt.Before(iteration.Start) && story.AcceptedAt.Before(iteration.Finish) {
+			points += story.Points()
+		}
+	}
+	return points
+}
+
+// RollingVelocity computes the velocity at the end of each of the given
+// iterations the way Tracker does: the points accepted in each iteration
+// are scaled by the team strength and averaged over the last
+// VelocityAveragedOver iterations. Iterations with no team strength are
+// left out of the average, and until one counts the velocity is the
+// project's initial velocity.
+func RollingVelocity(project tracker.Project, iterations []tracker.Iteration, stories []tracker.Story) []IterationVelocity {
+	window := project.VelocityAveragedOver
+	if window <= 0 {
+		window = defaultVelocityAveragedOver
+	}
+
+	sorted := make([]tracker.Iteration, len(iterations))
+	copy(sorted, iterations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+
+	velocities := make([]IterationVelocity, 0, len(sorted))
+	adjusted := make([]float64, 0, len(sorted))
+
+	for _, iteration := range sorted {
+		points := AcceptedPoints(iteration, stories)
+
+		if iteration.TeamStrength > 0 {
+			adjusted = append(adjusted, float64(points)/iteration.TeamStrength)
+		}
+
+		velocity := float64(project.InitialVelocity)
+		if len(adjusted) > 0 {
+			first := len(adjusted) - window
+			if first < 0 {
+				first = 0
+			}
+
+			total := 0.0
+			for _, value := range adjusted[first:] {
+				total += value
+			}
+			velocity = total / float64(len(adjusted)-first)
+		}
+
+		velocities = append(velocities, IterationVelocity{
+			Number:   iteration.Number,
+			Start:    iteration.Start,
+			Finish:   iteration.Finish,
+			Points:   points,
+			Velocity: velocity,
+		})
+	}
+
+	return velocities
+}
+
+// Velocity returns the project's current velocity given its done
+// iterations, falling back to the project's initial velocity when there
+// are none.
+func Velocity(project tracker.Project, iterations []tracker.Iteration, stories []tracker.Story) float64 {
+	velocities := RollingVelocity(project, iterations, stories)
+	if len(velocities) == 0 {
+		return float64(project.InitialVelocity)
+	}
+
+	return velocities[len(velocities)-1].Velocity
+}
@@ -0,0 +1,145 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package analytics_test
+
+import (
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+	"github.com/deoxxa/go-tracker/analytics"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Velocity", func() {
+	var (
+		week  = 7 * 24 * time.Hour
+		start = time.Date(2015, 7, 6, 7, 0, 0, 0, time.UTC)
+
+		project    tracker.Project
+		iterations []tracker.Iteration
+		stories    []tracker.Story
+	)
+
+	accepted := func(estimate int, at time.Time) tracker.Story {
+		return tracker.Story{Estimate: tracker.Estimate(estimate), State: tracker.StoryStateAccepted, AcceptedAt: &at}
+	}
+
+	BeforeEach(func() {
+		project = tracker.Project{IterationLength: 1, VelocityAveragedOver: 2, InitialVelocity: 10}
+
+		for i := 0; i < 4; i++ {
+			iterations = append(iterations, tracker.Iteration{
+				Number:       i + 1,
+				TeamStrength: 1,
+				Start:        start.Add(time.Duration(i) * week),
+				Finish:       start.Add(time.Duration(i+1) * week),
+			})
+		}
+		iterations[2].TeamStrength = 0.5
+
+		stories = []tracker.Story{
+			accepted(3, start.Add(time.Hour)),
+			accepted(5, start.Add(2*time.Hour)),
+			accepted(4, start.Add(week+time.Hour)),
+			accepted(3, start.Add(2*week+time.Hour)),
+			accepted(6, start.Add(3*week+time.Hour)),
+			{Estimate: tracker.Estimate(8), State: tracker.StoryStateStarted},
+		}
+	})
+
+	AfterEach(func() {
+		iterations = nil
+	})
+
+	It("works out the length of the project's iterations", func() {
+		Expect(analytics.IterationLength(project)).To(Equal(week))
+	})
+
+	It("sums the points accepted in an iteration", func() {
+		Expect(analytics.AcceptedPoints(iterations[0], stories)).To(Equal(8))
+	})
+
+	It("averages over the project's window, scaling by team strength", func() {
+		velocities := analytics.RollingVelocity(project, iterations, stories)
+
+		Expect(velocities).To(HaveLen(4))
+		Expect(velocities[0].Velocity).To(Equal(8.0))
+		Expect(velocities[1].Velocity).To(Equal(6.0))
+		Expect(velocities[2].Points).To(Equal(3))
+		Expect(velocities[2].Velocity).To(Equal(5.0))
+		Expect(velocities[3].Velocity).To(Equal(6.0))
+
+		Expect(analytics.Velocity(project, iterations, stories)).To(Equal(6.0))
+	})
+
+	It("leaves iterations with no team strength out of the average", func() {
+		iterations[0].TeamStrength = 0
+		iterations[1].TeamStrength = 0
+
+		velocities := analytics.RollingVelocity(project, iterations, stories)
+
+		Expect(velocities).To(HaveLen(4))
+		Expect(velocities[0].Points).To(Equal(8))
+		Expect(velocities[0].Velocity).To(Equal(10.0))
+		Expect(velocities[1].Velocity).To(Equal(10.0))
+		Expect(velocities[2].Velocity).To(Equal(6.0))
+		Expect(velocities[3].Velocity).To(Equal(6.0))
+	})
+
+	It("falls back to the initial velocity", func() {
+		Expect(analytics.Velocity(project, nil, stories)).To(Equal(10.0))
+	})
+
+	Describe("burnup", func() {
+		It("samples scope and accepted points", func() {
+			created := start.Add(week)
+			stories[5].CreatedAt = &created
+
+			points := analytics.Burnup(stories, start, start.Add(4*week), week)
+
+			Expect(points).To(HaveLen(5))
+			Expect(points[0]).To(Equal(analytics.BurnupPoint{At: start, Scope: 21, Accepted: 0, Remaining: 21}))
+			Expect(points[1]).To(Equal(analytics.BurnupPoint{At: start.Add(week), Scope: 29, Accepted: 8, Remaining: 21}))
+			Expect(points[4].Accepted).To(Equal(21))
+			Expect(points[4].Remaining).To(Equal(8))
+		})
+	})
+
+	Describe("forecasting", func() {
+		It("counts the whole iterations needed at the current velocity", func() {
+			backlog := []tracker.Story{
+				{Estimate: tracker.Estimate(5), Labels: []tracker.Label{{Name: "shields"}}},
+				{Estimate: tracker.Estimate(8)},
+				{ID: 900, Type: tracker.StoryTypeRelease},
+				{Estimate: tracker.Estimate(3), Labels: []tracker.Label{{Name: "shields"}}},
+			}
+
+			forecast, err := analytics.ForecastCompletion(analytics.UpToRelease(backlog, 900), 6, start, week)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(forecast.RemainingPoints).To(Equal(13))
+			Expect(forecast.Iterations).To(Equal(3))
+			Expect(forecast.Completion).To(Equal(start.Add(3 * week)))
+
+			forecast, err = analytics.ForecastCompletion(analytics.WithLabel(backlog, "shields"), 6, start, week)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(forecast.Iterations).To(Equal(2))
+		})
+
+		It("needs a velocity to forecast remaining work", func() {
+			_, err := analytics.ForecastCompletion(stories, 0, start, week)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
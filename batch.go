@@ -0,0 +1,248 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Batch collects GET requests for resources in one or more projects so
+// that they can be made in a single request to Tracker's aggregator
+// endpoint:
+//
+//	results, err := client.Batch().
+//		InProject(99).Story(560).StoryTasks(560).
+//		InProject(100).Story(570).
+//		Do()
+//	story, err := results.InProject(99).Story(560)
+//	tasks, err := results.InProject(99).StoryTasks(560)
+type Batch struct {
+	conn connection
+	urls []string
+	err  error
+}
+
+func (c Client) Batch() *Batch {
+	return &Batch{conn: c.conn}
+}
+
+// InProject returns a view of the batch which adds requests for resources
+// in the project.
+func (b *Batch) InProject(projectID int) ProjectBatch {
+	return ProjectBatch{
+		batch: b,
+		project: ProjectClient{
+			id:   projectID,
+			conn: b.conn,
+		},
+	}
+}
+
+type ProjectBatch struct {
+	batch   *Batch
+	project ProjectClient
+}
+
+func (p ProjectBatch) InProject(projectID int) ProjectBatch {
+	return p.batch.InProject(projectID)
+}
+
+func (p ProjectBatch) Story(storyID int) ProjectBatch {
+	return p.add(fmt.Sprintf("/stories/%d", storyID))
+}
+
+func (p ProjectBatch) StoryTasks(storyID int) ProjectBatch {
+	return p.add(fmt.Sprintf("/stories/%d/tasks", storyID))
+}
+
+func (p ProjectBatch) StoryComments(storyID int) ProjectBatch {
+	return p.add(fmt.Sprintf("/stories/%d/comments", storyID))
+}
+
+func (p ProjectBatch) StoryBlockers(storyID int) ProjectBatch {
+	return p.add(fmt.Sprintf("/stories/%d/blockers", storyID))
+}
+
+func (p ProjectBatch) StoryActivity(storyID int) ProjectBatch {
+	return p.add(fmt.Sprintf("/stories/%d/activity", storyID))
+}
+
+func (p ProjectBatch) Do() (BatchResults, error) {
+	return p.batch.Do()
+}
+
+func (p ProjectBatch) add(path string) ProjectBatch {
+	url, err := p.project.requestURI(path)
+	if err != nil {
+		if p.batch.err == nil {
+			p.batch.err = err
+		}
+		return p
+	}
+
+	p.batch.urls = append(p.batch.urls, url)
+	return p
+}
+
+// Do makes the batched requests. An error is only returned if the batch as
+// a whole failed; errors for single requests are returned when their
+// results are read.
+func (b *Batch) Do() (BatchResults, error) {
+	if b.err != nil {
+		return BatchResults{}, b.err
+	}
+
+	conn := b.conn.named("tracker.Batch")
+	request, err := conn.CreateRequest("POST", "/aggregator", nil)
+	if err != nil {
+		return BatchResults{}, err
+	}
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(b.urls)
+
+	conn.AddJSONBodyReader(request, buffer)
+
+	results := BatchResults{conn: b.conn}
+	_, err = conn.Do(request, &results.responses)
+	if err != nil {
+		return BatchResults{}, err
+	}
+
+	return results, nil
+}
+
+type BatchItemError struct {
+	URL     string
+	Code    string
+	Message string
+}
+
+func (e BatchItemError) Error() string {
+	return fmt.Sprintf("batched request for %s failed (%s): %s", e.URL, e.Code, e.Message)
+}
+
+type BatchResults struct {
+	conn      connection
+	responses map[string]json.RawMessage
+}
+
+// InProject returns a view of the results for resources in the project.
+func (r BatchResults) InProject(projectID int) ProjectBatchResults {
+	return ProjectBatchResults{
+		results: r,
+		project: ProjectClient{
+			id:   projectID,
+			conn: r.conn,
+		},
+	}
+}
+
+// Errors returns the errors of every batched request which failed, keyed
+// by URL.
+func (r BatchResults) Errors() map[string]error {
+	errors := map[string]error{}
+	for url, response := range r.responses {
+		if err := batchItemError(url, response); err != nil {
+			errors[url] = err
+		}
+	}
+	return errors
+}
+
+type ProjectBatchResults struct {
+	results BatchResults
+	project ProjectClient
+}
+
+func (r ProjectBatchResults) Story(storyID int) (Story, error) {
+	var story Story
+	err := r.decode(fmt.Sprintf("/stories/%d", storyID), &story)
+	return story, err
+}
+
+func (r ProjectBatchResults) StoryTasks(storyID int) ([]Task, error) {
+	var tasks []Task
+	err := r.decode(fmt.Sprintf("/stories/%d/tasks", storyID), &tasks)
+	return tasks, err
+}
+
+func (r ProjectBatchResults) StoryComments(storyID int) ([]Comment, error) {
+	var comments []Comment
+	err := r.decode(fmt.Sprintf("/stories/%d/comments", storyID), &comments)
+	return comments, err
+}
+
+func (r ProjectBatchResults) StoryBlockers(storyID int) ([]Blocker, error) {
+	var blockers []Blocker
+	err := r.decode(fmt.Sprintf("/stories/%d/blockers", storyID), &blockers)
+	return blockers, err
+}
+
+func (r ProjectBatchResults) StoryActivity(storyID int) ([]Activity, error) {
+	var activities []Activity
+	err := r.decode(fmt.Sprintf("/stories/%d/activity", storyID), &activities)
+	return activities, err
+}
+
+func (r ProjectBatchResults) decode(path string, object interface{}) error {
+	url, err := r.project.requestURI(path)
+	if err != nil {
+		return err
+	}
+
+	return r.results.decode(url, object)
+}
+
+func (r BatchResults) decode(url string, object interface{}) error {
+	response, ok := r.responses[url]
+	if !ok {
+		return fmt.Errorf("no response for %s in batch", url)
+	}
+
+	if err := batchItemError(url, response); err != nil {
+		return err
+	}
+
+	unmarshal := json.Unmarshal
+	if r.conn.strict {
+		unmarshal = UnmarshalStrict
+	}
+
+	if err := unmarshal(response, object); err != nil {
+		return fmt.Errorf("invalid json response: %s", err)
+	}
+
+	return nil
+}
+
+func batchItemError(url string, response json.RawMessage) error {
+	var item struct {
+		Kind  string `json:"kind"`
+		Code  string `json:"code"`
+		Error string `json:"error"`
+	}
+
+	if json.Unmarshal(response, &item) != nil || item.Kind != "error" {
+		return nil
+	}
+
+	return BatchItemError{
+		URL:     url,
+		Code:    item.Code,
+		Message: item.Error,
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+var _ = Describe("Batching requests", func() {
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("makes all the requests through the aggregator", func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/services/v5/aggregator"),
+				ghttp.VerifyJSON(`[
+					"/services/v5/projects/99/stories/560",
+					"/services/v5/projects/99/stories/560/tasks",
+					"/services/v5/projects/100/stories/561/comments"
+				]`),
+				verifyTrackerToken(),
+
+				ghttp.RespondWith(http.StatusOK, `{
+					"/services/v5/projects/99/stories/560": `+Fixture("story.json")+`,
+					"/services/v5/projects/99/stories/560/tasks": `+Fixture("tasks.json")+`,
+					"/services/v5/projects/100/stories/561/comments": {
+						"kind": "error",
+						"code": "unfound_resource",
+						"error": "The object you tried to access could not be found."
+					}
+				}`),
+			),
+		)
+
+		client := tracker.NewClient("api-token")
+		client.SetBaseURL(server.URL())
+
+		results, err := client.Batch().
+			InProject(99).Story(560).StoryTasks(560).
+			InProject(100).StoryComments(561).
+			Do()
+		Expect(err).NotTo(HaveOccurred())
+
+		story, err := results.InProject(99).Story(560)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(story.Name).To(Equal("Tractor beam loses power intermittently"))
+
+		tasks, err := results.InProject(99).StoryTasks(560)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(3))
+
+		_, err = results.InProject(100).StoryComments(561)
+		Expect(err).To(Equal(tracker.BatchItemError{
+			URL:     "/services/v5/projects/100/stories/561/comments",
+			Code:    "unfound_resource",
+			Message: "The object you tried to access could not be found.",
+		}))
+		Expect(results.Errors()).To(HaveLen(1))
+
+		_, err = results.InProject(99).StoryBlockers(560)
+		Expect(err).To(MatchError("no response for /services/v5/projects/99/stories/560/blockers in batch"))
+	})
+
+	It("returns an error if the whole batch fails", func() {
+		server.AppendHandlers(
+			ghttp.RespondWith(http.StatusInternalServerError, ""),
+		)
+
+		client := tracker.NewClient("api-token")
+		client.SetBaseURL(server.URL())
+
+		_, err := client.Batch().InProject(99).Story(560).Do()
+		Expect(err).To(HaveOccurred())
+	})
+})
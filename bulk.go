@@ -0,0 +1,145 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrBulkStopped is the error of the items of a bulk operation which were
+// not attempted because an earlier item failed and StopOnError was set.
+var ErrBulkStopped = errors.New("not attempted: bulk operation stopped after an error")
+
+type BulkOptions struct {
+	// Concurrency is the number of requests made at once. It defaults to
+	// eight.
+	Concurrency int
+
+	// StopOnError stops starting new items once one has failed.
+	StopOnError bool
+
+	// Progress, if set, is called after each item completes with the
+	// number of completed items and the total. Items skipped because of
+	// StopOnError count as completed. Calls are never concurrent.
+	Progress func(completed int, total int)
+}
+
+// BulkResult is the outcome of one item of a bulk operation. Index is the
+// item's position in the input. StoryID is set for skipped items too, except
+// for stories which were never created.
+type BulkResult struct {
+	Index   int
+	StoryID int
+	Story   Story
+	Err     error
+}
+
+// BulkUpdateStories updates many stories at once. Results are in the same
+// order as the stories. The error is that of the first failed item when
+// StopOnError is set.
+func (p ProjectClient) BulkUpdateStories(stories []Story, options BulkOptions) ([]BulkResult, error) {
+	p.conn = p.conn.named("tracker.BulkUpdateStories")
+	storyID := func(i int) int { return stories[i].ID }
+	return runBulk(len(stories), options, storyID, func(i int) BulkResult {
+		story, err := p.UpdateStory(stories[i])
+		return BulkResult{StoryID: stories[i].ID, Story: story, Err: err}
+	})
+}
+
+func (p ProjectClient) BulkCreateStories(stories []NewStory, options BulkOptions) ([]BulkResult, error) {
+	p.conn = p.conn.named("tracker.BulkCreateStories")
+	return runBulk(len(stories), options, nil, func(i int) BulkResult {
+		story, err := p.CreateStory(stories[i])
+		return BulkResult{StoryID: story.ID, Story: story, Err: err}
+	})
+}
+
+func (p ProjectClient) BulkDeleteStories(storyIDs []int, options BulkOptions) ([]BulkResult, error) {
+	p.conn = p.conn.named("tracker.BulkDeleteStories")
+	storyID := func(i int) int { return storyIDs[i] }
+	return runBulk(len(storyIDs), options, storyID, func(i int) BulkResult {
+		err := p.DeleteStory(storyIDs[i])
+		return BulkResult{StoryID: storyIDs[i], Err: err}
+	})
+}
+
+// runBulk runs operation for every item. storyID gives the ID of an item
+// ahead of running it, so that skipped items can be told apart; it is nil
+// when there is no ID until the operation has run.
+func runBulk(total int, options BulkOptions, storyID func(i int) int, operation func(i int) BulkResult) ([]BulkResult, error) {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = maxConcurrentRequests
+	}
+
+	results := make([]BulkResult, total)
+	indexes := make(chan int)
+
+	var (
+		mutex     sync.Mutex
+		wg        sync.WaitGroup
+		completed int
+		firstErr  error
+	)
+
+	for worker := 0; worker < concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				mutex.Lock()
+				stopped := options.StopOnError && firstErr != nil
+				mutex.Unlock()
+
+				var result BulkResult
+				if stopped {
+					result.Err = ErrBulkStopped
+					if storyID != nil {
+						result.StoryID = storyID(i)
+					}
+				} else {
+					result = operation(i)
+				}
+				result.Index = i
+
+				mutex.Lock()
+				results[i] = result
+				if !stopped && result.Err != nil && firstErr == nil {
+					firstErr = result.Err
+				}
+
+				completed++
+				if options.Progress != nil {
+					options.Progress(completed, total)
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < total; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	if options.StopOnError {
+		return results, firstErr
+	}
+
+	return results, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker_test
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+var _ = Describe("Bulk story operations", func() {
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		server.RouteToHandler("PUT", regexp.MustCompile(`^/services/v5/projects/99/stories/\d+$`), func(w http.ResponseWriter, r *http.Request) {
+			id := strings.TrimPrefix(r.URL.Path, "/services/v5/projects/99/stories/")
+			if id == "13" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"id": %s, "estimate": 3}`, id)
+		})
+		server.RouteToHandler("DELETE", regexp.MustCompile(`^/services/v5/projects/99/stories/\d+$`), func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		server.RouteToHandler("POST", "/services/v5/projects/99/stories", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"id": 1234}`)
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("updates every story, reporting results in order", func() {
+		var stories []tracker.Story
+		for id := 1; id <= 20; id++ {
+			stories = append(stories, tracker.Story{ID: id, Estimate: tracker.Estimate(3)})
+		}
+
+		var mutex sync.Mutex
+		var progress []int
+
+		client := tracker.NewClient("api-token")
+		client.SetBaseURL(server.URL())
+		results, err := client.InProject(99).BulkUpdateStories(stories, tracker.BulkOptions{
+			Concurrency: 4,
+			Progress: func(completed int, total int) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				Expect(total).To(Equal(20))
+				progress = append(progress, completed)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(20))
+		Expect(server.ReceivedRequests()).To(HaveLen(20))
+		Expect(progress).To(HaveLen(20))
+		Expect(progress[19]).To(Equal(20))
+
+		for i, result := range results {
+			Expect(result.Index).To(Equal(i))
+			Expect(result.StoryID).To(Equal(i + 1))
+			if result.StoryID == 13 {
+				Expect(result.Err).To(HaveOccurred())
+			} else {
+				Expect(result.Err).NotTo(HaveOccurred())
+				Expect(result.Story.ID).To(Equal(i + 1))
+			}
+		}
+	})
+
+	It("can stop after the first error", func() {
+		var stories []tracker.Story
+		for id := 13; id <= 20; id++ {
+			stories = append(stories, tracker.Story{ID: id})
+		}
+
+		var progress []int
+		client := tracker.NewClient("api-token")
+		client.SetBaseURL(server.URL())
+		results, err := client.InProject(99).BulkUpdateStories(stories, tracker.BulkOptions{
+			Concurrency: 1,
+			StopOnError: true,
+			Progress: func(completed int, total int) {
+				progress = append(progress, completed)
+			},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(results[0].Err).To(Equal(err))
+		for i, result := range results[1:] {
+			Expect(result.Err).To(Equal(tracker.ErrBulkStopped))
+			Expect(result.StoryID).To(Equal(stories[i+1].ID))
+		}
+		Expect(progress).To(Equal([]int{1, 2, 3, 4, 5, 6, 7, 8}))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("creates and deletes stories", func() {
+		client := tracker.NewClient("api-token")
+		client.SetBaseURL(server.URL())
+
+		results, err := client.InProject(99).BulkCreateStories([]tracker.NewStory{{Name: "a"}, {Name: "b"}}, tracker.BulkOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[1].StoryID).To(Equal(1234))
+
+		results, err = client.InProject(99).BulkDeleteStories([]int{1, 2, 3}, tracker.BulkOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(3))
+		Expect(results[2].StoryID).To(Equal(3))
+		Expect(results[2].Err).NotTo(HaveOccurred())
+	})
+})
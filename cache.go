@@ -0,0 +1,376 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a response kept for revalidating a GET request.
+type CacheEntry struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	StoredAt     time.Time   `json:"stored_at"`
+}
+
+func (e CacheEntry) size() int64 {
+	size := int64(len(e.Body) + len(e.ETag) + len(e.LastModified))
+	for key, values := range e.Header {
+		for _, value := range values {
+			size += int64(len(key) + len(value))
+		}
+	}
+	return size
+}
+
+// CacheStore keeps cache entries. Implementations must be safe for
+// concurrent use.
+type CacheStore interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+	Delete(key string)
+}
+
+// MemoryCache keeps entries in memory, dropping the least recently used
+// once they take up more than its size limit.
+type MemoryCache struct {
+	maxBytes int64
+
+	mutex   sync.Mutex
+	size    int64
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewMemoryCache makes a MemoryCache holding up to maxBytes of responses,
+// or any amount if maxBytes is zero.
+func NewMemoryCache(maxBytes int64) *MemoryCache {
+	return &MemoryCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+func (c *MemoryCache) Get(key string) (CacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*memoryCacheItem).entry, true
+}
+
+func (c *MemoryCache) Set(key string, entry CacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.remove(key)
+
+	if c.maxBytes > 0 && entry.size() > c.maxBytes {
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	c.size += entry.size()
+
+	for c.maxBytes > 0 && c.size > c.maxBytes {
+		c.remove(c.order.Back().Value.(*memoryCacheItem).key)
+	}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.remove(key)
+}
+
+func (c *MemoryCache) remove(key string) {
+	element, ok := c.entries[key]
+	if !ok {
+		return
+	}
+
+	c.order.Remove(element)
+	delete(c.entries, key)
+	c.size -= element.Value.(*memoryCacheItem).entry.size()
+}
+
+// DiskCache keeps entries as files in a directory, so that they outlive
+// the process. Once the files take up more than its size limit the least
+// recently used are removed.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mutex sync.Mutex
+}
+
+// NewDiskCache makes a DiskCache in dir, creating it if needed. A maxBytes
+// of zero lets the cache grow without bound.
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+	}, nil
+}
+
+func (c *DiskCache) Get(key string) (CacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	path := c.path(key)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		os.Remove(path)
+		return CacheEntry{}, false
+	}
+
+	now := time.Now()
+	os.Chtimes(path, now, now)
+
+	return entry, true
+}
+
+func (c *DiskCache) Set(key string, entry CacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	if c.maxBytes > 0 && int64(len(data)) > c.maxBytes {
+		os.Remove(c.path(key))
+		return
+	}
+
+	// Write then rename, so a reader never sees half an entry.
+	temporary, err := ioutil.TempFile(c.dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = temporary.Write(data)
+	temporary.Close()
+	if err != nil || os.Rename(temporary.Name(), c.path(key)) != nil {
+		os.Remove(temporary.Name())
+		return
+	}
+
+	c.trim()
+}
+
+func (c *DiskCache) Delete(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	os.Remove(c.path(key))
+}
+
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// trim removes the least recently used entries until the cache fits.
+func (c *DiskCache) trim() {
+	if c.maxBytes <= 0 {
+		return
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return
+	}
+
+	var infos []os.FileInfo
+	var size int64
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+		size += info.Size()
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+
+	for _, info := range infos {
+		if size <= c.maxBytes {
+			return
+		}
+		if os.Remove(filepath.Join(c.dir, info.Name())) == nil {
+			size -= info.Size()
+		}
+	}
+}
+
+type cacheDoer struct {
+	next  Doer
+	store CacheStore
+	ttl   time.Duration
+}
+
+// Do revalidates GET requests against the cached response, if there is
+// one, and answers from the cache when Tracker reports it unchanged.
+func (d cacheDoer) Do(request *http.Request) (*http.Response, error) {
+	if request.Method != "GET" {
+		return d.next.Do(request)
+	}
+
+	key := cacheKey(request)
+	entry, cached := d.store.Get(key)
+	if cached && d.ttl > 0 && time.Since(entry.StoredAt) > d.ttl {
+		d.store.Delete(key)
+		cached = false
+	}
+
+	if cached {
+		request = request.Clone(request.Context())
+		if entry.ETag != "" {
+			request.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			request.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	response, err := d.next.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached && response.StatusCode == http.StatusNotModified {
+		response.Body.Close()
+
+		entry = refreshEntry(entry, response.Header)
+		entry.StoredAt = time.Now()
+		d.store.Set(key, entry)
+
+		return cachedResponse(request, entry), nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return response, nil
+	}
+
+	etag := response.Header.Get("ETag")
+	lastModified := response.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return response, nil
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	d.store.Set(key, CacheEntry{
+		ETag:         etag,
+		LastModified: lastModified,
+		Header:       response.Header.Clone(),
+		Body:         body,
+		StoredAt:     time.Now(),
+	})
+
+	return response, nil
+}
+
+// cacheKey keeps responses apart by token as well as URL, since different
+// people can see different things at the same URL.
+func cacheKey(request *http.Request) string {
+	hash := sha256.Sum256([]byte(request.Header.Get("X-TrackerToken") + " " + request.URL.String()))
+	return hex.EncodeToString(hash[:])
+}
+
+// refreshEntry updates a cached entry with the headers of a 304 response,
+// as RFC 7234 section 4.3.4 asks, so that headers such as the project
+// version and pagination are not served stale.
+func refreshEntry(entry CacheEntry, header http.Header) CacheEntry {
+	merged := entry.Header.Clone()
+	if merged == nil {
+		merged = http.Header{}
+	}
+	for key, values := range header {
+		if key == "Content-Length" {
+			continue
+		}
+		merged[key] = append([]string(nil), values...)
+	}
+	entry.Header = merged
+
+	if etag := header.Get("ETag"); etag != "" {
+		entry.ETag = etag
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		entry.LastModified = lastModified
+	}
+
+	return entry
+}
+
+func cachedResponse(request *http.Request, entry CacheEntry) *http.Response {
+	header := entry.Header.Clone()
+	header.Set("Content-Length", strconv.Itoa(len(entry.Body)))
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       request,
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+var _ = Describe("Caching responses", func() {
+	var (
+		server *ghttp.Server
+		client *tracker.Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		client = tracker.NewClient("api-token")
+		client.SetBaseURL(server.URL())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	etagged := http.Header{
+		"ETag":                       []string{`"v62"`},
+		"X-Tracker-Pagination-Total": []string{"3"},
+	}
+
+	cacheBehaviour := func(store func() tracker.CacheStore) {
+		It("revalidates with the ETag and decodes the cached body when unchanged", func() {
+			client.SetCache(store(), 0)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories"),
+					ghttp.RespondWith(http.StatusOK, Fixture("stories.json"), etagged),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories"),
+					ghttp.VerifyHeaderKV("If-None-Match", `"v62"`),
+					ghttp.RespondWith(http.StatusNotModified, ""),
+				),
+			)
+
+			first, _, err := client.InProject(99).Stories(tracker.StoriesQuery{})
+			Expect(err).NotTo(HaveOccurred())
+
+			second, pagination, err := client.InProject(99).Stories(tracker.StoriesQuery{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second).To(Equal(first))
+			Expect(pagination.Total).To(Equal(3))
+		})
+	}
+
+	Context("in memory", func() {
+		cacheBehaviour(func() tracker.CacheStore {
+			return tracker.NewMemoryCache(0)
+		})
+	})
+
+	Context("on disk", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "tracker-cache")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		cacheBehaviour(func() tracker.CacheStore {
+			cache, err := tracker.NewDiskCache(dir, 0)
+			Expect(err).NotTo(HaveOccurred())
+			return cache
+		})
+	})
+
+	It("fetches afresh once entries are older than the TTL", func() {
+		client.SetCache(tracker.NewMemoryCache(0), time.Nanosecond)
+
+		server.AppendHandlers(
+			ghttp.RespondWith(http.StatusOK, Fixture("project.json"), etagged),
+			ghttp.CombineHandlers(
+				func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.Header.Get("If-None-Match")).To(BeEmpty())
+				},
+				ghttp.RespondWith(http.StatusOK, Fixture("project.json"), etagged),
+			),
+		)
+
+		_, err := client.InProject(99).Project()
+		Expect(err).NotTo(HaveOccurred())
+		time.Sleep(time.Millisecond)
+		_, err = client.InProject(99).Project()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("takes fresh headers such as the project version from a revalidation", func() {
+		client.SetCache(tracker.NewMemoryCache(0), 0)
+
+		server.AppendHandlers(
+			ghttp.RespondWith(http.StatusOK, `{"id": 560, "estimate": 1}`, http.Header{
+				"ETag":                      []string{`"v45"`},
+				"X-Tracker-Project-Version": []string{"45"},
+			}),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560"),
+				ghttp.VerifyHeaderKV("If-None-Match", `"v45"`),
+				ghttp.RespondWith(http.StatusNotModified, "", http.Header{
+					"X-Tracker-Project-Version": []string{"46"},
+				}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/560"),
+				ghttp.VerifyHeaderKV("X-Tracker-Project-Version", "46"),
+				ghttp.RespondWith(http.StatusOK, `{"id": 560, "estimate": 2}`),
+			),
+		)
+
+		_, version, err := client.InProject(99).StoryWithVersion(560)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal(45))
+
+		story, err := client.InProject(99).ModifyStory(560, func(story *tracker.Story) error {
+			story.Estimate = tracker.Estimate(story.Points() + 1)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(story.Points()).To(Equal(2))
+	})
+
+	It("drops the least recently used entries to stay within its size", func() {
+		cache := tracker.NewMemoryCache(10)
+		cache.Set("a", tracker.CacheEntry{Body: []byte("12345")})
+		cache.Set("b", tracker.CacheEntry{Body: []byte("12345")})
+		_, ok := cache.Get("a")
+		Expect(ok).To(BeTrue())
+
+		cache.Set("c", tracker.CacheEntry{Body: []byte("12345")})
+
+		_, ok = cache.Get("b")
+		Expect(ok).To(BeFalse())
+		_, ok = cache.Get("a")
+		Expect(ok).To(BeTrue())
+		_, ok = cache.Get("c")
+		Expect(ok).To(BeTrue())
+	})
+})
@@ -14,11 +14,24 @@
 package tracker
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
 )
 
 var DefaultURL = "https://www.pivotaltracker.com"
 
+// maxConcurrentRequests bounds the requests made at once by methods which
+// fan out over many resources.
+const maxConcurrentRequests = 8
+
+// Client talks to Tracker with an API token. The project, account and
+// workspace clients it makes share its settings as they were when they were
+// made, so call the Set methods and Use before InProject and friends.
 type Client struct {
 	conn connection
 }
@@ -29,7 +42,49 @@ func NewClient(token string) *Client {
 	}
 }
 
+// SetBaseURL makes the client talk to the Tracker at baseURL rather than
+// DefaultURL.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.conn.baseURL = baseURL
+}
+
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	c.conn.client = httpClient
+}
+
+// Use adds middleware around every request, in the order given.
+func (c *Client) Use(middleware ...Middleware) {
+	c.conn.middleware = append(append([]Middleware(nil), c.conn.middleware...), middleware...)
+}
+
+// SetLogger logs every request at level, or at least at warning level when
+// it fails. The API token is never logged; a nil logger turns logging off.
+func (c *Client) SetLogger(logger *slog.Logger, level slog.Level) {
+	c.conn.logger = logger
+	c.conn.logLevel = level
+}
+
+// SetTelemetry reports a span and metrics for every request.
+func (c *Client) SetTelemetry(telemetry Telemetry) {
+	c.conn.telemetry = &telemetry
+}
+
+// SetCache keeps GET responses in store and revalidates them with their
+// ETag or Last-Modified date. Entries older than a non-zero ttl are fetched
+// afresh; a nil store turns caching off.
+func (c *Client) SetCache(store CacheStore, ttl time.Duration) {
+	c.conn.cache = store
+	c.conn.cacheTTL = ttl
+}
+
+// SetStrict makes decoding a response fail if it holds a story type or
+// state this package does not know about.
+func (c *Client) SetStrict(strict bool) {
+	c.conn.strict = strict
+}
+
 func (c Client) Me() (me Me, err error) {
+	c.conn = c.conn.named("tracker.Me")
 	request, err := c.conn.CreateRequest("GET", "/me", nil)
 	if err != nil {
 		return me, err
@@ -47,9 +102,97 @@ func (c Client) InProject(projectId int) ProjectClient {
 	}
 }
 
+func (c Client) InAccount(accountID int) AccountClient {
+	return AccountClient{
+		id:   accountID,
+		conn: c.conn,
+	}
+}
+
+func (c Client) Accounts() ([]Account, error) {
+	c.conn = c.conn.named("tracker.Accounts")
+	request, err := c.conn.CreateRequest("GET", "/accounts", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []Account
+	_, err = c.conn.Do(request, &accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (c Client) Account(accountID int) (Account, error) {
+	c.conn = c.conn.named("tracker.Account")
+	url := fmt.Sprintf("/accounts/%d", accountID)
+	request, err := c.conn.CreateRequest("GET", url, nil)
+	if err != nil {
+		return Account{}, err
+	}
+
+	var account Account
+	_, err = c.conn.Do(request, &account)
+	return account, err
+}
+
 func (c Client) Story(storyID int) (Story, error) {
+	c.conn = c.conn.named("tracker.Story")
+	return c.StoryWithQuery(storyID, StoryQuery{})
+}
+
+type StoryResult struct {
+	Story Story
+	Err   error
+}
+
+// StoriesByIDs fetches stories from any project, making several requests
+// at once. Every ID is in the result, with the error if its story could not
+// be fetched.
+func (c Client) StoriesByIDs(storyIDs []int) map[int]StoryResult {
+	c.conn = c.conn.named("tracker.StoriesByIDs")
+	results := make(map[int]StoryResult, len(storyIDs))
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxConcurrentRequests)
+
+	for _, storyID := range storyIDs {
+		mutex.Lock()
+		_, seen := results[storyID]
+		results[storyID] = StoryResult{}
+		mutex.Unlock()
+
+		if seen {
+			continue
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func(storyID int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			story, err := c.Story(storyID)
+
+			mutex.Lock()
+			results[storyID] = StoryResult{Story: story, Err: err}
+			mutex.Unlock()
+		}(storyID)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func (c Client) StoryWithQuery(storyID int, query StoryQuery) (Story, error) {
+	c.conn = c.conn.named("tracker.StoryWithQuery")
 	url := fmt.Sprintf("/stories/%d", storyID)
-	request, err := c.conn.CreateRequest("GET", url, nil)
+	request, err := c.conn.CreateRequest("GET", url, query.Query())
 	if err != nil {
 		return Story{}, err
 	}
@@ -63,3 +206,119 @@ func (c Client) Story(storyID int) (Story, error) {
 
 	return story, err
 }
+
+func (c Client) Workspaces() ([]Workspace, error) {
+	c.conn = c.conn.named("tracker.Workspaces")
+	request, err := c.conn.CreateRequest("GET", "/my/workspaces", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspaces []Workspace
+	_, err = c.conn.Do(request, &workspaces)
+	if err != nil {
+		return nil, err
+	}
+
+	return workspaces, nil
+}
+
+func (c Client) Workspace(workspaceID int) (Workspace, error) {
+	c.conn = c.conn.named("tracker.Workspace")
+	url := fmt.Sprintf("/my/workspaces/%d", workspaceID)
+	request, err := c.conn.CreateRequest("GET", url, nil)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	var workspace Workspace
+	_, err = c.conn.Do(request, &workspace)
+	return workspace, err
+}
+
+func (c Client) CreateWorkspace(workspace Workspace) (Workspace, error) {
+	c.conn = c.conn.named("tracker.CreateWorkspace")
+	request, err := c.conn.CreateRequest("POST", "/my/workspaces", nil)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(workspace)
+
+	c.conn.AddJSONBodyReader(request, buffer)
+
+	var createdWorkspace Workspace
+	_, err = c.conn.Do(request, &createdWorkspace)
+	return createdWorkspace, err
+}
+
+func (c Client) UpdateWorkspace(workspace Workspace) (Workspace, error) {
+	c.conn = c.conn.named("tracker.UpdateWorkspace")
+	url := fmt.Sprintf("/my/workspaces/%d", workspace.ID)
+	request, err := c.conn.CreateRequest("PUT", url, nil)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(workspace)
+
+	c.conn.AddJSONBodyReader(request, buffer)
+
+	var updatedWorkspace Workspace
+	_, err = c.conn.Do(request, &updatedWorkspace)
+	return updatedWorkspace, err
+}
+
+func (c Client) SetWorkspaceProjects(workspaceID int, projectIDs []int) (Workspace, error) {
+	c.conn = c.conn.named("tracker.SetWorkspaceProjects")
+	url := fmt.Sprintf("/my/workspaces/%d", workspaceID)
+	request, err := c.conn.CreateRequest("PUT", url, nil)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	if projectIDs == nil {
+		projectIDs = []int{}
+	}
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(map[string]interface{}{"project_ids": projectIDs})
+
+	c.conn.AddJSONBodyReader(request, buffer)
+
+	var updatedWorkspace Workspace
+	_, err = c.conn.Do(request, &updatedWorkspace)
+	return updatedWorkspace, err
+}
+
+func (c Client) DeleteWorkspace(workspaceID int) error {
+	c.conn = c.conn.named("tracker.DeleteWorkspace")
+	url := fmt.Sprintf("/my/workspaces/%d", workspaceID)
+	request, err := c.conn.CreateRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.conn.Do(request, nil)
+	return err
+}
+
+func (c Client) InWorkspace(workspaceID int) ([]ProjectClient, error) {
+	// The project clients are made from c, not named, so that their own
+	// calls are not reported as InWorkspace.
+	named := c
+	named.conn = c.conn.named("tracker.InWorkspace")
+	workspace, err := named.Workspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]ProjectClient, 0, len(workspace.ProjectIDs))
+	for _, projectID := range workspace.ProjectIDs {
+		projects = append(projects, c.InProject(projectID))
+	}
+
+	return projects, nil
+}
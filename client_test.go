@@ -14,8 +14,16 @@
 package tracker_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"regexp"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -105,6 +113,25 @@ var _ = Describe("Tracker Client", func() {
 			Expect(err.Error()).To(MatchRegexp("failed to create request"))
 		})
 
+		It("talks to its own base URL rather than the default", func() {
+			other := ghttp.NewServer()
+			defer other.Close()
+
+			other.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/services/v5/me"),
+				verifyTrackerToken(),
+
+				ghttp.RespondWith(http.StatusOK, Fixture("me.json")),
+			))
+
+			client.SetBaseURL(other.URL())
+			me, err := client.Me()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(me.Username).To(Equal("vader"))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+
 		It("returns an error if the response JSON is broken", func() {
 			server.AppendHandlers(ghttp.CombineHandlers(
 				ghttp.RespondWith(http.StatusOK, `{"`),
@@ -118,6 +145,185 @@ var _ = Describe("Tracker Client", func() {
 		})
 	})
 
+	Describe("wrapping requests in middleware", func() {
+		It("runs each request through the middleware in order", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/services/v5/projects/99"),
+				ghttp.VerifyHeaderKV("X-Trace", "outer", "inner"),
+				ghttp.RespondWith(http.StatusOK, Fixture("project.json")),
+			))
+
+			var statuses []int
+			tag := func(name string) tracker.Middleware {
+				return func(next tracker.Doer) tracker.Doer {
+					return tracker.DoerFunc(func(request *http.Request) (*http.Response, error) {
+						request.Header.Add("X-Trace", name)
+						response, err := next.Do(request)
+						if err == nil {
+							statuses = append(statuses, response.StatusCode)
+						}
+						return response, err
+					})
+				}
+			}
+
+			client.Use(tag("outer"))
+			client.Use(tag("inner"))
+
+			project, err := client.InProject(99).Project()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(project.Name).To(Equal("Death Star"))
+			Expect(statuses).To(Equal([]int{http.StatusOK, http.StatusOK}))
+		})
+
+		It("lets middleware answer without sending the request", func() {
+			client.Use(func(next tracker.Doer) tracker.Doer {
+				return tracker.DoerFunc(func(request *http.Request) (*http.Response, error) {
+					return nil, errors.New("offline")
+				})
+			})
+
+			_, err := client.Me()
+			Expect(err).To(MatchError("failed to make request: offline"))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Describe("logging requests", func() {
+		var logs *bytes.Buffer
+
+		BeforeEach(func() {
+			logs = &bytes.Buffer{}
+			logger := slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			client.SetLogger(logger, slog.LevelInfo)
+		})
+
+		It("logs each call without the token", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories"),
+				ghttp.RespondWith(http.StatusOK, Fixture("stories.json"), http.Header{
+					"X-Tracker-Pagination-Total":    []string{"4"},
+					"X-Tracker-Pagination-Offset":   []string{"0"},
+					"X-Tracker-Pagination-Limit":    []string{"100"},
+					"X-Tracker-Pagination-Returned": []string{"4"},
+				}),
+			))
+
+			_, _, err := client.InProject(99).Stories(tracker.StoriesQuery{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logs.String()).NotTo(ContainSubstring("api-token"))
+
+			var entry map[string]interface{}
+			Expect(json.Unmarshal(logs.Bytes(), &entry)).To(Succeed())
+			Expect(entry["level"]).To(Equal("INFO"))
+			Expect(entry["method"]).To(Equal("GET"))
+			Expect(entry["path"]).To(Equal("/services/v5/projects/99/stories"))
+			Expect(entry["status"]).To(BeNumerically("==", 200))
+			Expect(entry).To(HaveKey("duration"))
+			Expect(entry["pagination"]).To(HaveKeyWithValue("total", BeNumerically("==", 4)))
+			Expect(entry["request_header"]).To(HaveKeyWithValue("X-Trackertoken", "[REDACTED]"))
+		})
+
+		It("logs Tracker's error code when a call fails", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"kind": "error", "code": "unfound_resource", "error": "The object you tried to access could not be found."}`),
+			))
+
+			_, err := client.InProject(99).Story(560)
+			Expect(err).To(MatchError(ContainSubstring("unfound_resource")))
+
+			var entry map[string]interface{}
+			Expect(json.Unmarshal(logs.Bytes(), &entry)).To(Succeed())
+			Expect(entry["level"]).To(Equal("WARN"))
+			Expect(entry["status"]).To(BeNumerically("==", 404))
+			Expect(entry["error_code"]).To(Equal("unfound_resource"))
+		})
+	})
+
+	Describe("reporting telemetry", func() {
+		var (
+			tracer  *fakeTracer
+			metrics *fakeMetrics
+		)
+
+		BeforeEach(func() {
+			tracer = &fakeTracer{}
+			metrics = &fakeMetrics{}
+			client.SetTelemetry(tracker.Telemetry{Tracer: tracer, Metrics: metrics})
+		})
+
+		It("reports a span and metrics for each request", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories"),
+				ghttp.VerifyHeaderKV("Traceparent", "span-tracker.Stories"),
+				ghttp.RespondWith(http.StatusOK, Fixture("stories.json")),
+			))
+
+			client.Use(func(next tracker.Doer) tracker.Doer {
+				return tracker.DoerFunc(func(request *http.Request) (*http.Response, error) {
+					request.Header.Set("Traceparent", request.Context().Value(spanKey{}).(string))
+					return next.Do(request)
+				})
+			})
+
+			_, _, err := client.InProject(99).Stories(tracker.StoriesQuery{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tracer.started).To(Equal([]string{"tracker.Stories"}))
+			Expect(metrics.observed).To(HaveLen(1))
+			Expect(metrics.observed[0].Operation).To(Equal("tracker.Stories"))
+			Expect(metrics.observed[0].ProjectID).To(Equal(99))
+			Expect(metrics.observed[0].StatusCode).To(Equal(http.StatusOK))
+			Expect(tracer.ended).To(Equal(metrics.observed))
+		})
+
+		It("reports Tracker's error code", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"kind": "error", "code": "unfound_resource"}`),
+			))
+
+			err := client.InProject(99).DeleteStory(560)
+			Expect(err).To(HaveOccurred())
+
+			Expect(metrics.observed[0].Operation).To(Equal("tracker.DeleteStory"))
+			Expect(metrics.observed[0].StatusCode).To(Equal(http.StatusNotFound))
+			Expect(metrics.observed[0].ErrorCode).To(Equal("unfound_resource"))
+		})
+
+		It("names requests after the method called rather than the URL", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "story_type": "chore", "current_state": "unstarted"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/560"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "current_state": "started"}`),
+				),
+			)
+
+			_, err := client.InProject(99).StartStory(560)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tracer.started).To(Equal([]string{"tracker.StartStory", "tracker.StartStory"}))
+		})
+
+		It("names calls made through workspace project clients after their own method", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"id": 400, "project_ids": [99]}`),
+				ghttp.RespondWith(http.StatusOK, Fixture("stories.json")),
+			)
+
+			projects, err := client.InWorkspace(400)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = projects[0].Stories(tracker.StoriesQuery{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tracer.started).To(Equal([]string{"tracker.InWorkspace", "tracker.Stories"}))
+		})
+	})
+
 	Describe("retrieving a story by ID", func() {
 		It("gets one story", func() {
 			server.AppendHandlers(
@@ -136,6 +342,404 @@ var _ = Describe("Tracker Client", func() {
 			Expect(story.ID).To(Equal(560))
 			Expect(story.Name).To(Equal("Tractor beam loses power intermittently"))
 		})
+
+		It("rejects unknown story states only for strict clients", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"id": 560, "current_state": "done"}`),
+				ghttp.RespondWith(http.StatusOK, `{"id": 560, "current_state": "done"}`),
+			)
+
+			lenient := tracker.NewClient("api-token")
+			strict := tracker.NewClient("api-token")
+			strict.SetStrict(true)
+
+			_, err := lenient.Story(560)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = strict.Story(560)
+			Expect(err).To(MatchError(`invalid json response: unknown story state: "done"`))
+		})
+	})
+
+	Describe("listing accounts", func() {
+		It("gets all the accounts", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/accounts"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, Fixture("accounts.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			accounts, err := client.Accounts()
+			Expect(accounts).To(HaveLen(2))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("gets one account", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/accounts/100"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 100, "name": "Galactic Empire"}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			account, err := client.Account(100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(account.Name).To(Equal("Galactic Empire"))
+		})
+
+		It("gets the projects in an account", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects", "account_ids=100"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, "["+Fixture("project.json")+"]"),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			projects, err := client.InAccount(100).Projects()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projects).To(HaveLen(1))
+			Expect(projects[0].AccountID).To(Equal(100))
+		})
+	})
+
+	Describe("managing account memberships", func() {
+		It("lists the memberships", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/accounts/100/memberships"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, Fixture("account_memberships.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			memberships, err := client.InAccount(100).Memberships()
+			Expect(memberships).To(HaveLen(2))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("POSTs new members", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/services/v5/accounts/100/memberships"),
+					ghttp.VerifyJSON(`{
+						"email": "tarkin@deathstar.mil",
+						"admin": false,
+						"project_creator": true,
+						"timekeeper": false,
+						"time_enterer": false
+					}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 108, "project_creator": true}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			membership, err := client.InAccount(100).AddMember(tracker.NewAccountMembership{
+				Email: "tarkin@deathstar.mil",
+				AccountPermissions: tracker.AccountPermissions{
+					ProjectCreator: true,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(membership.ID).To(Equal(108))
+			Expect(membership.ProjectCreator).To(BeTrue())
+		})
+
+		It("PUTs permission changes", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/accounts/100/memberships/101"),
+					ghttp.VerifyJSON(`{
+						"admin": true,
+						"project_creator": true,
+						"timekeeper": false,
+						"time_enterer": false
+					}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 101, "admin": true, "project_creator": true}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			membership, err := client.InAccount(100).UpdateMember(101, tracker.AccountPermissions{
+				Admin:          true,
+				ProjectCreator: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(membership.Admin).To(BeTrue())
+		})
+
+		It("DELETEs members", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/services/v5/accounts/100/memberships/101"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+			err := client.InAccount(100).RemoveMember(101)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("managing workspaces", func() {
+		It("lists the workspaces", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/my/workspaces"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, Fixture("workspaces.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			workspaces, err := client.Workspaces()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workspaces).To(HaveLen(2))
+			Expect(workspaces[0].ProjectIDs).To(Equal([]int{98, 99}))
+		})
+
+		It("POSTs new workspaces", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/services/v5/my/workspaces"),
+					ghttp.VerifyJSON(`{"name":"Imperial Navy","project_ids":[99]}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 400, "name": "Imperial Navy", "project_ids": [99]}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			workspace, err := client.CreateWorkspace(tracker.Workspace{
+				Name:       "Imperial Navy",
+				ProjectIDs: []int{99},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workspace.ID).To(Equal(400))
+		})
+
+		It("PUTs workspace changes", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/my/workspaces/400"),
+					ghttp.VerifyJSON(`{"id":400,"name":"Imperial Fleet"}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 400, "name": "Imperial Fleet"}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			workspace, err := client.UpdateWorkspace(tracker.Workspace{
+				ID:   400,
+				Name: "Imperial Fleet",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workspace.Name).To(Equal("Imperial Fleet"))
+		})
+
+		It("sets the projects in a workspace", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/my/workspaces/400"),
+					ghttp.VerifyJSON(`{"project_ids":[]}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 400, "project_ids": []}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			workspace, err := client.SetWorkspaceProjects(400, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workspace.ProjectIDs).To(BeEmpty())
+		})
+
+		It("DELETEs workspaces", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/services/v5/my/workspaces/400"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+			err := client.DeleteWorkspace(400)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns project clients for every project in a workspace", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/my/workspaces/400"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 400, "project_ids": [98, 99]}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, Fixture("stories.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			projects, err := client.InWorkspace(400)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projects).To(HaveLen(2))
+
+			stories, _, err := projects[1].Stories(tracker.StoriesQuery{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stories).To(HaveLen(4))
+		})
+
+		It("returns the projects in a workspace as interfaces through API", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/my/workspaces/400"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 400, "project_ids": [98, 99]}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories"),
+					ghttp.RespondWith(http.StatusOK, Fixture("stories.json")),
+				),
+			)
+
+			projects, err := tracker.API(client).InWorkspace(400)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projects).To(HaveLen(2))
+
+			stories, _, err := projects[1].Stories(tracker.StoriesQuery{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stories).To(HaveLen(4))
+		})
+	})
+
+	Describe("retrieving many stories by ID", func() {
+		It("fetches stories from any project at once, reporting errors per story", func() {
+			server.RouteToHandler("GET", regexp.MustCompile(`^/services/v5/stories/\d+$`), func(w http.ResponseWriter, r *http.Request) {
+				id := strings.TrimPrefix(r.URL.Path, "/services/v5/stories/")
+				if id == "404" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprintf(w, `{"id": %s}`, id)
+			})
+
+			client := tracker.NewClient("api-token")
+
+			results := client.StoriesByIDs([]int{560, 561, 404, 560})
+			Expect(results).To(HaveLen(3))
+			Expect(results[560].Err).NotTo(HaveOccurred())
+			Expect(results[560].Story.ID).To(Equal(560))
+			Expect(results[561].Story.ID).To(Equal(561))
+			Expect(results[404].Err).To(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("fetches stories in a project using the id filter", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories", "filter=id%3A560%2C561+includedone%3Atrue&limit=2"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `[{"id": 560}, {"id": 561}]`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			stories, err := client.InProject(99).StoriesByIDs([]int{560, 561})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stories).To(HaveLen(2))
+		})
+
+		It("splits long lists of IDs across requests", func() {
+			var ids []int
+			for i := 0; i < 400; i++ {
+				ids = append(ids, 1000000+i)
+			}
+
+			server.RouteToHandler("GET", "/services/v5/projects/99/stories", func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(len(r.URL.String())).To(BeNumerically("<", 2000))
+				fmt.Fprint(w, `[{"id": 1}]`)
+			})
+
+			client := tracker.NewClient("api-token")
+
+			stories, err := client.InProject(99).StoriesByIDs(ids)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stories).To(HaveLen(3))
+			Expect(server.ReceivedRequests()).To(HaveLen(3))
+		})
+	})
+
+	Describe("retrieving a story with extra fields", func() {
+		It("decodes the nested resources", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560", "fields=%3Adefault%2Cowners%2Ctasks%2Ccomments%28text%2Cperson%29"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{
+						"id": 560,
+						"owners": [{"id": 101, "username": "vader"}],
+						"tasks": [{"id": 52167427, "description": "some-task-description", "complete": true}],
+						"comments": [{"text": "some-comment", "person": {"id": 100, "username": "palpatine"}}]
+					}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			story, err := client.InProject(99).StoryWithQuery(560, tracker.StoryQuery{
+				Fields: tracker.Fields{
+					tracker.FieldsDefault,
+					"owners",
+					"tasks",
+					tracker.NestedFields("comments", "text", "person"),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(story.Owners[0].Username).To(Equal("vader"))
+			Expect(story.Tasks[0].IsComplete).To(BeTrue())
+			Expect(story.Comments[0].Text).To(Equal("some-comment"))
+			Expect(story.Comments[0].Person.Username).To(Equal("palpatine"))
+		})
 	})
 
 	Describe("listing stories", func() {
@@ -228,6 +832,75 @@ var _ = Describe("Tracker Client", func() {
 		})
 	})
 
+	Describe("searching a project", func() {
+		It("gets the matching stories and epics", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/search", "query=label%3A%22rebel+bases%22"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, Fixture("search.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			results, err := client.InProject(99).Search(tracker.SearchQuery{
+				Filter: tracker.Filter().Label("rebel bases").Terms(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results.Stories.Stories).To(HaveLen(2))
+			Expect(results.Stories.TotalPoints).To(Equal(5))
+			Expect(results.Stories.TotalPointsCompleted).To(Equal(3))
+			Expect(results.Epics.Epics).To(HaveLen(1))
+			Expect(results.Epics.Epics[0].Label.Name).To(Equal("rebel bases"))
+		})
+	})
+
+	Describe("listing iterations", func() {
+		It("gets the iterations in the requested scope", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/iterations", "limit=2&scope=done"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, Fixture("iterations.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			iterations, _, err := client.InProject(99).Iterations(tracker.IterationsQuery{
+				Scope: tracker.IterationScopeDone,
+				Limit: 2,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(iterations).To(HaveLen(2))
+			Expect(iterations[1].Number).To(Equal(15))
+			Expect(iterations[1].TeamStrength).To(Equal(0.5))
+		})
+	})
+
+	Describe("retrieving a project", func() {
+		It("gets the project itself", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, Fixture("project.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			project, err := client.InProject(99).Project()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(project.ID).To(Equal(99))
+			Expect(project.Name).To(Equal("Death Star"))
+		})
+	})
+
 	Describe("listing project memberships", func() {
 		It("gets all the project memberships", func() {
 			server.AppendHandlers(
@@ -247,50 +920,164 @@ var _ = Describe("Tracker Client", func() {
 		})
 	})
 
-	Describe("listing a story's activity", func() {
-		It("gets the story's activity", func() {
+	Describe("adding a project member", func() {
+		It("POSTs by email", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/services/v5/projects/99/memberships"),
+					ghttp.VerifyJSON(`{"email":"tarkin@deathstar.mil","role":"member"}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{
+						"kind": "project_membership",
+						"id": 108,
+						"project_id": 99,
+						"role": "member",
+						"person": {"id": 108, "email": "tarkin@deathstar.mil"}
+					}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			membership, err := client.InProject(99).AddMember(tracker.NewProjectMembership{
+				Email: "tarkin@deathstar.mil",
+				Role:  tracker.ProjectRoleMember,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(membership.ID).To(Equal(108))
+			Expect(membership.Person.Email).To(Equal("tarkin@deathstar.mil"))
+		})
+	})
+
+	Describe("updating a project member's role", func() {
+		It("PUTs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/memberships/101"),
+					ghttp.VerifyJSON(`{"role":"viewer"}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 101, "role": "viewer"}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			membership, err := client.InProject(99).UpdateMemberRole(101, tracker.ProjectRoleViewer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(membership.Role).To(Equal(tracker.ProjectRoleViewer))
+		})
+	})
+
+	Describe("removing a project member", func() {
+		It("DELETEs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/services/v5/projects/99/memberships/101"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+			err := client.InProject(99).RemoveMember(101)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("listing a story's activity", func() {
+		It("gets the story's activity", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560/activity"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, Fixture("activities.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			activities, err := client.InProject(99).StoryActivity(560, tracker.ActivityQuery{})
+			Expect(activities).To(HaveLen(4))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("allows different queries to be made", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(
+						"GET",
+						"/services/v5/projects/99/stories/560/activity",
+						"limit=2&occurred_after=1000000000000&occurred_before=1433091819000&offset=1&since_version=1",
+					),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, Fixture("activities.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			query := tracker.ActivityQuery{
+				Limit:          2,
+				Offset:         1,
+				OccurredBefore: 1433091819000,
+				OccurredAfter:  1000000000000,
+				SinceVersion:   1,
+			}
+			activities, err := client.InProject(99).StoryActivity(560, query)
+			Expect(activities).To(HaveLen(4))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("listing story transitions", func() {
+		It("gets a story's transitions", func() {
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560/activity"),
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560/transitions"),
 					verifyTrackerToken(),
 
-					ghttp.RespondWith(http.StatusOK, Fixture("activities.json")),
+					ghttp.RespondWith(http.StatusOK, Fixture("story_transitions.json")),
 				),
 			)
 
 			client := tracker.NewClient("api-token")
 
-			activities, err := client.InProject(99).StoryActivity(560, tracker.ActivityQuery{})
-			Expect(activities).To(HaveLen(4))
+			transitions, err := client.InProject(99).StoryTransitions(560)
+			Expect(transitions).To(HaveLen(4))
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("allows different queries to be made", func() {
+		It("gets the project's transitions between dates", func() {
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest(
 						"GET",
-						"/services/v5/projects/99/stories/560/activity",
-						"limit=2&occurred_after=1000000000000&occurred_before=1433091819000&offset=1&since_version=1",
+						"/services/v5/projects/99/story_transitions",
+						"occurred_after=2015-07-20T00%3A00%3A00Z&occurred_before=2015-07-23T00%3A00%3A00Z",
 					),
 					verifyTrackerToken(),
 
-					ghttp.RespondWith(http.StatusOK, Fixture("activities.json")),
+					ghttp.RespondWith(http.StatusOK, Fixture("story_transitions.json"), http.Header{
+						"X-Tracker-Pagination-Total": []string{"4"},
+					}),
 				),
 			)
 
 			client := tracker.NewClient("api-token")
 
-			query := tracker.ActivityQuery{
-				Limit:          2,
-				Offset:         1,
-				OccurredBefore: 1433091819000,
-				OccurredAfter:  1000000000000,
-				SinceVersion:   1,
+			query := tracker.StoryTransitionsQuery{
+				OccurredAfter:  time.Date(2015, 7, 20, 0, 0, 0, 0, time.UTC),
+				OccurredBefore: time.Date(2015, 7, 23, 0, 0, 0, 0, time.UTC),
 			}
-			activities, err := client.InProject(99).StoryActivity(560, query)
-			Expect(activities).To(HaveLen(4))
+			transitions, pagination, err := client.InProject(99).Transitions(query)
 			Expect(err).NotTo(HaveOccurred())
+			Expect(transitions).To(HaveLen(4))
+			Expect(pagination.Total).To(Equal(4))
 		})
 	})
 
@@ -391,6 +1178,12 @@ var _ = Describe("Tracker Client", func() {
 	Describe("delivering a story", func() {
 		It("HTTP PUTs it in its place", func() {
 			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/15225523"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 15225523, "story_type": "bug", "current_state": "finished"}`),
+				),
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/15225523"),
 					ghttp.VerifyJSON(`{"current_state":"delivered"}`),
@@ -408,6 +1201,12 @@ var _ = Describe("Tracker Client", func() {
 
 		It("HTTP PUTs it in its place with a comment", func() {
 			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/15225523"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 15225523, "story_type": "bug", "current_state": "finished"}`),
+				),
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/15225523"),
 					ghttp.VerifyJSON(`{"current_state":"delivered"}`),
@@ -430,6 +1229,102 @@ var _ = Describe("Tracker Client", func() {
 			err := client.InProject(99).DeliverStoryWithComment(15225523, comment)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("accepts a response with no content", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"id": 15225523, "story_type": "bug", "current_state": "finished"}`),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/15225523"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			err := client.InProject(99).DeliverStory(15225523)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("refuses to deliver a story which has not been finished", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/15225523"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 15225523, "story_type": "feature", "current_state": "started"}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			err := client.InProject(99).DeliverStory(15225523)
+			Expect(err).To(BeAssignableToTypeOf(tracker.InvalidTransitionError{}))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("moving a story through its workflow", func() {
+		It("starts estimated features", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "story_type": "feature", "estimate": 2, "current_state": "unstarted"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/560"),
+					ghttp.VerifyJSON(`{"current_state":"started"}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "story_type": "feature", "estimate": 2, "current_state": "started"}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			story, err := client.InProject(99).StartStory(560)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(story.State).To(Equal(tracker.StoryStateStarted))
+		})
+
+		It("refuses to start unestimated features", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "story_type": "feature", "current_state": "unscheduled"}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			_, err := client.InProject(99).StartStory(560)
+			Expect(err).To(MatchError("cannot move feature 560 from unscheduled to started: unestimated features cannot be started"))
+		})
+
+		It("rejects delivered stories with a comment", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "story_type": "bug", "current_state": "delivered"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/560"),
+					ghttp.VerifyJSON(`{"current_state":"rejected"}`),
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "story_type": "bug", "current_state": "rejected"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/services/v5/projects/99/stories/560/comments"),
+					ghttp.VerifyJSON(`{"text":"the tractor beam still flickers"}`),
+					ghttp.RespondWith(http.StatusOK, `{"id": 111, "text": "the tractor beam still flickers"}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			story, err := client.InProject(99).RejectStory(560, "the tractor beam still flickers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(story.State).To(Equal(tracker.StoryStateRejected))
+		})
 	})
 
 	Describe("creating a story", func() {
@@ -455,7 +1350,7 @@ var _ = Describe("Tracker Client", func() {
 
 			client := tracker.NewClient("api-token")
 
-			story, err := client.InProject(99).CreateStory(tracker.Story{
+			story, err := client.InProject(99).CreateStory(tracker.NewStory{
 				Name: "Exhaust ports are ray shielded",
 				Blockers: []tracker.Blocker{
 					{
@@ -514,6 +1409,257 @@ var _ = Describe("Tracker Client", func() {
 		})
 	})
 
+	Describe("moving stories", func() {
+		It("PUTs a story before another", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/1234"),
+					ghttp.VerifyJSON(`{"before_id":560}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 1234, "project_id": 99}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			story, err := client.InProject(99).MoveStory(1234, tracker.StoryPosition{BeforeID: 560})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(story.ID).To(Equal(1234))
+		})
+
+		It("requires exactly one of before or after", func() {
+			client := tracker.NewClient("api-token")
+
+			_, err := client.InProject(99).MoveStory(1234, tracker.StoryPosition{})
+			Expect(err).To(HaveOccurred())
+
+			_, err = client.InProject(99).MoveStory(1234, tracker.StoryPosition{BeforeID: 1, AfterID: 2})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("moves several stories preserving their relative order", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/3"),
+					ghttp.VerifyJSON(`{"before_id":560}`),
+					ghttp.RespondWith(http.StatusOK, `{"id": 3}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/1"),
+					ghttp.VerifyJSON(`{"after_id":3}`),
+					ghttp.RespondWith(http.StatusOK, `{"id": 1}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/2"),
+					ghttp.VerifyJSON(`{"after_id":1}`),
+					ghttp.RespondWith(http.StatusOK, `{"id": 2}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			stories, err := client.InProject(99).MoveStories([]int{3, 1, 2}, tracker.StoryPosition{BeforeID: 560})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stories).To(HaveLen(3))
+			Expect(stories[2].ID).To(Equal(2))
+		})
+
+		It("PUTs a story into another project", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/1234"),
+					ghttp.VerifyJSON(`{"project_id":98}`),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 1234, "project_id": 98}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			story, err := client.InProject(99).MoveStoryToProject(1234, 98)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(story.ProjectID).To(Equal(98))
+		})
+	})
+
+	Describe("updating a story at a known project version", func() {
+		It("sends the expected version", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/1234"),
+					ghttp.VerifyHeaderKV("X-Tracker-Project-Version", "45"),
+					verifyTrackerToken(),
+
+					ghttp.RespondWith(http.StatusOK, `{"id": 1234}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			_, err := client.InProject(99).AtVersion(45).UpdateStory(tracker.Story{ID: 1234})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns a conflict error if the project has moved on", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/1234"),
+					ghttp.RespondWith(http.StatusConflict, `{
+						"kind": "error",
+						"code": "version_conflict",
+						"error": "The project has changed since you last read it."
+					}`, http.Header{"X-Tracker-Project-Version": []string{"46"}}),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			_, err := client.InProject(99).AtVersion(45).UpdateStory(tracker.Story{ID: 1234})
+			Expect(err).To(Equal(tracker.ConflictError{
+				ProjectVersion: 46,
+				Code:           "version_conflict",
+				Message:        "The project has changed since you last read it.",
+			}))
+		})
+
+		It("retries modifications which conflict", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/1234"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 1234, "estimate": 1}`, http.Header{"X-Tracker-Project-Version": []string{"45"}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/1234"),
+					ghttp.VerifyHeaderKV("X-Tracker-Project-Version", "45"),
+					ghttp.RespondWith(http.StatusConflict, ""),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/1234"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 1234, "estimate": 2}`, http.Header{"X-Tracker-Project-Version": []string{"46"}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/1234"),
+					ghttp.VerifyHeaderKV("X-Tracker-Project-Version", "46"),
+					ghttp.VerifyJSON(`{"estimate": 3}`),
+					ghttp.RespondWith(http.StatusOK, `{"id": 1234, "estimate": 3}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			story, err := client.InProject(99).ModifyStory(1234, func(story *tracker.Story) error {
+				story.Estimate = tracker.Estimate(story.Points() + 1)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(story.Points()).To(Equal(3))
+		})
+
+		It("gives up if the modification fails", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/1234"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 1234}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			_, err := client.InProject(99).ModifyStory(1234, func(story *tracker.Story) error {
+				return errors.New("the force is not with you")
+			})
+			Expect(err).To(MatchError("the force is not with you"))
+		})
+
+		It("only sends the fields which were modified", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560"),
+					ghttp.RespondWith(http.StatusOK, Fixture("story.json"), http.Header{"X-Tracker-Project-Version": []string{"45"}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/560"),
+					ghttp.VerifyJSON(`{"name": "Tractor beam is fixed", "labels": []}`),
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "name": "Tractor beam is fixed"}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			story, err := client.InProject(99).ModifyStory(560, func(story *tracker.Story) error {
+				story.Name = "Tractor beam is fixed"
+				story.Labels = nil
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(story.Name).To(Equal("Tractor beam is fixed"))
+		})
+
+		It("does not save a story which was not modified", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560"),
+					ghttp.RespondWith(http.StatusOK, Fixture("story.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			story, err := client.InProject(99).ModifyStory(560, func(story *tracker.Story) error {
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(story.ID).To(Equal(560))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("saves changes made in place to lists and the estimate", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560"),
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "estimate": 1, "owner_ids": [1, 2], "labels": [{"id": 10, "name": "weapons"}]}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/services/v5/projects/99/stories/560"),
+					ghttp.VerifyJSON(`{"estimate": 5, "owner_ids": [7, 2], "labels": [{"id": 10, "name": "defences"}]}`),
+					ghttp.RespondWith(http.StatusOK, `{"id": 560, "estimate": 5}`),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			_, err := client.InProject(99).ModifyStory(560, func(story *tracker.Story) error {
+				*story.Estimate = 5
+				story.OwnerIDs[0] = 7
+				story.Labels[0].Name = "defences"
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("refuses to drop changes to fields it cannot save", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/services/v5/projects/99/stories/560"),
+					ghttp.RespondWith(http.StatusOK, Fixture("story.json")),
+				),
+			)
+
+			client := tracker.NewClient("api-token")
+
+			_, err := client.InProject(99).ModifyStory(560, func(story *tracker.Story) error {
+				story.ProjectID = 100
+				story.Blockers = nil
+				return nil
+			})
+			Expect(err).To(MatchError("ModifyStory cannot save changes to project_id, blockers"))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
 	Describe("deleting a story", func() {
 		It("DELETES", func() {
 			server.AppendHandlers(
@@ -608,9 +1754,14 @@ var _ = Describe("Tracker Client", func() {
 				Text: "some-tracker-comment",
 			})
 
+			createdAt := time.Date(2017, 3, 7, 12, 0, 0, 0, time.UTC)
 			Expect(comment).To(Equal(tracker.Comment{
-				ID:   111,
-				Text: "some-tracker-comment",
+				ID:        111,
+				StoryID:   560,
+				PersonID:  101,
+				Text:      "some-tracker-comment",
+				CreatedAt: &createdAt,
+				UpdatedAt: &createdAt,
 			}))
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -658,3 +1809,31 @@ func verifyTrackerToken() http.HandlerFunc {
 
 	return ghttp.VerifyHeader(headers)
 }
+
+type spanKey struct{}
+
+type fakeTracer struct {
+	started []string
+	ended   []tracker.RequestInfo
+}
+
+func (t *fakeTracer) StartSpan(ctx context.Context, operation string, projectID int) (context.Context, tracker.Span) {
+	t.started = append(t.started, operation)
+	return context.WithValue(ctx, spanKey{}, "span-"+operation), fakeSpan{t}
+}
+
+type fakeSpan struct {
+	tracer *fakeTracer
+}
+
+func (s fakeSpan) End(info tracker.RequestInfo) {
+	s.tracer.ended = append(s.tracer.ended, info)
+}
+
+type fakeMetrics struct {
+	observed []tracker.RequestInfo
+}
+
+func (m *fakeMetrics) ObserveRequest(info tracker.RequestInfo) {
+	m.observed = append(m.observed, info)
+}
@@ -0,0 +1,443 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+const usage = `usage: tracker <command> [arguments] [--json] [--token TOKEN] [--project ID]
+
+commands:
+  me                                    show the authenticated user
+  stories list [--state S] [--label L]  list a project's stories
+  story show ID                         show a story
+  story create --name NAME [--type T] [--state S] [--description D] [--label L]...
+                                        add a story to a project
+  story deliver ID [-m COMMENT]         deliver a finished story
+  task add STORY_ID DESCRIPTION         add a task to a story
+  comment add STORY_ID TEXT             comment on a story
+`
+
+var errNoProject = errors.New("no project: set TRACKER_PROJECT, pass --project or add project_id to the config file")
+
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// command is the state shared by every subcommand once its arguments have
+// been parsed.
+type command struct {
+	flags *flag.FlagSet
+	out   output
+
+	getenv    func(string) string
+	token     string
+	projectID int
+}
+
+func newCommand(name string, getenv func(string) string, stdout io.Writer) *command {
+	c := &command{
+		flags:  flag.NewFlagSet("tracker "+name, flag.ContinueOnError),
+		out:    output{w: stdout},
+		getenv: getenv,
+	}
+
+	c.flags.SetOutput(ioutil.Discard)
+	c.flags.BoolVar(&c.out.json, "json", false, "print JSON rather than a table")
+	c.flags.StringVar(&c.token, "token", "", "Tracker API token")
+	c.flags.IntVar(&c.projectID, "project", 0, "project ID")
+
+	return c
+}
+
+// parse parses the flags, which may come before, between or after the
+// positional arguments, and checks there are as many of those as wanted.
+func (c *command) parse(args []string, wanted ...string) ([]string, error) {
+	var positional []string
+	for {
+		if err := c.flags.Parse(args); err != nil {
+			return nil, usageError{err.Error()}
+		}
+
+		args = c.flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if len(positional) != len(wanted) {
+		return nil, usageError{fmt.Sprintf("%s takes %s", c.flags.Name(), describeArguments(wanted))}
+	}
+
+	return positional, nil
+}
+
+func describeArguments(names []string) string {
+	if len(names) == 0 {
+		return "no arguments"
+	}
+	return strings.Join(names, " ")
+}
+
+func (c *command) client() (*tracker.Client, config, error) {
+	conf, err := loadConfig(c.getenv)
+	if err != nil {
+		return nil, config{}, err
+	}
+
+	if c.token != "" {
+		conf.Token = c.token
+	}
+	if c.projectID != 0 {
+		conf.ProjectID = c.projectID
+	}
+
+	if conf.Token == "" {
+		return nil, config{}, errNoToken
+	}
+	client := tracker.NewClient(conf.Token)
+	if conf.URL != "" {
+		client.SetBaseURL(conf.URL)
+	}
+
+	return client, conf, nil
+}
+
+func (c *command) project() (tracker.ProjectClient, error) {
+	client, conf, err := c.client()
+	if err != nil {
+		return tracker.ProjectClient{}, err
+	}
+	if conf.ProjectID == 0 {
+		return tracker.ProjectClient{}, errNoProject
+	}
+
+	return client.InProject(conf.ProjectID), nil
+}
+
+// storyProject finds the project a story belongs to, when no project has
+// been configured, by looking the story up.
+func (c *command) storyProject(storyID int) (tracker.ProjectClient, error) {
+	client, conf, err := c.client()
+	if err != nil {
+		return tracker.ProjectClient{}, err
+	}
+	if conf.ProjectID != 0 {
+		return client.InProject(conf.ProjectID), nil
+	}
+
+	story, err := client.Story(storyID)
+	if err != nil {
+		return tracker.ProjectClient{}, err
+	}
+
+	return client.InProject(story.ProjectID), nil
+}
+
+func run(args []string, getenv func(string) string, stdout io.Writer, stderr io.Writer) int {
+	err := dispatch(args, getenv, stdout)
+	if err == nil {
+		return 0
+	}
+
+	if _, ok := err.(usageError); ok {
+		fmt.Fprintf(stderr, "tracker: %s\n\n%s", err, usage)
+		return 2
+	}
+
+	fmt.Fprintf(stderr, "tracker: %s\n", err)
+	return 1
+}
+
+func dispatch(args []string, getenv func(string) string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return usageError{"no command given"}
+	}
+
+	name := args[0]
+	args = args[1:]
+	if name == "stories" || name == "story" || name == "task" || name == "comment" {
+		if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+			return usageError{fmt.Sprintf("%s needs a subcommand", name)}
+		}
+		name += " " + args[0]
+		args = args[1:]
+	}
+
+	c := newCommand(name, getenv, stdout)
+
+	switch name {
+	case "me":
+		return c.me(args)
+	case "stories list":
+		return c.listStories(args)
+	case "story show":
+		return c.showStory(args)
+	case "story create":
+		return c.createStory(args)
+	case "story deliver":
+		return c.deliverStory(args)
+	case "task add":
+		return c.addTask(args)
+	case "comment add":
+		return c.addComment(args)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		return usageError{fmt.Sprintf("unknown command %q", name)}
+	}
+}
+
+func (c *command) me(args []string) error {
+	if _, err := c.parse(args); err != nil {
+		return err
+	}
+
+	client, _, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	me, err := client.Me()
+	if err != nil {
+		return err
+	}
+
+	return c.out.me(me)
+}
+
+func (c *command) listStories(args []string) error {
+	var state, label string
+	c.flags.StringVar(&state, "state", "", "only list stories in this state")
+	c.flags.StringVar(&label, "label", "", "only list stories with this label")
+
+	if _, err := c.parse(args); err != nil {
+		return err
+	}
+
+	query := tracker.StoriesQuery{Label: label}
+	if state != "" {
+		parsed, err := tracker.ParseStoryState(state)
+		if err != nil {
+			return usageError{err.Error()}
+		}
+		query.State = parsed
+	}
+
+	project, err := c.project()
+	if err != nil {
+		return err
+	}
+
+	stories, _, err := project.Stories(query)
+	if err != nil {
+		return err
+	}
+
+	return c.out.stories(stories)
+}
+
+func (c *command) showStory(args []string) error {
+	positional, err := c.parse(args, "ID")
+	if err != nil {
+		return err
+	}
+
+	storyID, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	client, _, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	story, err := client.Story(storyID)
+	if err != nil {
+		return err
+	}
+
+	return c.out.story(story)
+}
+
+func (c *command) createStory(args []string) error {
+	var name, storyType, state, description string
+	var labels stringsFlag
+	c.flags.StringVar(&name, "name", "", "the story's name")
+	c.flags.StringVar(&storyType, "type", "", "feature, bug, chore or release")
+	c.flags.StringVar(&state, "state", "", "the story's state")
+	c.flags.StringVar(&description, "description", "", "the story's description")
+	c.flags.Var(&labels, "label", "a label for the story; may be repeated")
+
+	if _, err := c.parse(args); err != nil {
+		return err
+	}
+	if name == "" {
+		return usageError{"story create needs --name"}
+	}
+
+	story := tracker.NewStory{
+		Name:        name,
+		Description: description,
+	}
+	if storyType != "" {
+		parsed, err := tracker.ParseStoryType(storyType)
+		if err != nil {
+			return usageError{err.Error()}
+		}
+		story.Type = parsed
+	}
+	if state != "" {
+		parsed, err := tracker.ParseStoryState(state)
+		if err != nil {
+			return usageError{err.Error()}
+		}
+		story.State = parsed
+	}
+	for _, label := range labels {
+		story.Labels = append(story.Labels, tracker.Label{Name: label})
+	}
+
+	project, err := c.project()
+	if err != nil {
+		return err
+	}
+
+	created, err := project.CreateStory(story)
+	if err != nil {
+		return err
+	}
+
+	return c.out.story(created)
+}
+
+func (c *command) deliverStory(args []string) error {
+	var message string
+	c.flags.StringVar(&message, "m", "", "a comment to add as the story is delivered")
+
+	positional, err := c.parse(args, "ID")
+	if err != nil {
+		return err
+	}
+
+	storyID, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	project, err := c.storyProject(storyID)
+	if err != nil {
+		return err
+	}
+
+	if message != "" {
+		err = project.DeliverStoryWithComment(storyID, message)
+	} else {
+		err = project.DeliverStory(storyID)
+	}
+	if err != nil {
+		return err
+	}
+
+	story, err := project.Story(storyID)
+	if err != nil {
+		return err
+	}
+
+	return c.out.story(story)
+}
+
+func (c *command) addTask(args []string) error {
+	positional, err := c.parse(args, "STORY_ID", "DESCRIPTION")
+	if err != nil {
+		return err
+	}
+
+	storyID, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	project, err := c.storyProject(storyID)
+	if err != nil {
+		return err
+	}
+
+	task, err := project.CreateTask(storyID, tracker.Task{Description: positional[1]})
+	if err != nil {
+		return err
+	}
+
+	return c.out.task(task)
+}
+
+func (c *command) addComment(args []string) error {
+	positional, err := c.parse(args, "STORY_ID", "TEXT")
+	if err != nil {
+		return err
+	}
+
+	storyID, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	project, err := c.storyProject(storyID)
+	if err != nil {
+		return err
+	}
+
+	comment, err := project.CreateComment(storyID, tracker.Comment{Text: positional[1]})
+	if err != nil {
+		return err
+	}
+
+	return c.out.comment(comment)
+}
+
+func parseID(value string) (int, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, usageError{fmt.Sprintf("invalid ID %q", value)}
+	}
+	return id, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/deoxxa/go-tracker"
+	"github.com/deoxxa/go-tracker/trackertest"
+)
+
+var _ = Describe("tracker", func() {
+	var (
+		server  *trackertest.Server
+		project tracker.Project
+		env     map[string]string
+		stdout  *bytes.Buffer
+		stderr  *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		server = trackertest.NewServer()
+		project = server.AddProject(tracker.Project{Name: "Death Star"})
+
+		env = map[string]string{
+			"TRACKER_URL":   server.URL,
+			"TRACKER_TOKEN": server.Token,
+			"HOME":          filepath.Join(os.TempDir(), "tracker-no-home"),
+		}
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	runTracker := func(args ...string) int {
+		stdout.Reset()
+		stderr.Reset()
+		return run(args, func(key string) string { return env[key] }, stdout, stderr)
+	}
+
+	It("shows the authenticated user", func() {
+		Expect(runTracker("me")).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`Username\s+trackertest`))
+	})
+
+	It("needs a token", func() {
+		delete(env, "TRACKER_TOKEN")
+
+		Expect(runTracker("me")).To(Equal(1))
+		Expect(stderr.String()).To(ContainSubstring("no API token"))
+	})
+
+	It("reads the token and project from the config file", func() {
+		dir, err := ioutil.TempDir("", "tracker-config")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "config.json")
+		config := `{"token": "` + server.Token + `", "project_id": ` + strconv.Itoa(project.ID) + `}`
+		Expect(ioutil.WriteFile(path, []byte(config), 0600)).To(Succeed())
+
+		delete(env, "TRACKER_TOKEN")
+		env["TRACKER_CONFIG"] = path
+
+		Expect(runTracker("stories", "list")).To(Equal(0))
+		Expect(stdout.String()).To(HavePrefix("ID"))
+	})
+
+	It("fails if the config file it is given is missing", func() {
+		env["TRACKER_CONFIG"] = filepath.Join(os.TempDir(), "tracker-no-home", "config.json")
+
+		Expect(runTracker("me")).To(Equal(1))
+		Expect(stderr.String()).To(ContainSubstring("failed to read config file"))
+	})
+
+	It("creates, lists and delivers stories", func() {
+		env["TRACKER_PROJECT"] = strconv.Itoa(project.ID)
+
+		Expect(runTracker("story", "create", "--name", "Build the superlaser", "--type", "chore", "--label", "weapons")).To(Equal(0))
+		stories := server.Stories(project.ID)
+		Expect(stories).To(HaveLen(1))
+		Expect(stories[0].Labels[0].Name).To(Equal("weapons"))
+		id := strconv.Itoa(stories[0].ID)
+
+		server.AddStory(project.ID, tracker.Story{Name: "Trench run", State: tracker.StoryStateStarted})
+
+		Expect(runTracker("stories", "list", "--label", "weapons", "--json")).To(Equal(0))
+		var listed []tracker.Story
+		Expect(json.Unmarshal(stdout.Bytes(), &listed)).To(Succeed())
+		Expect(listed).To(HaveLen(1))
+		Expect(listed[0].Name).To(Equal("Build the superlaser"))
+
+		Expect(runTracker("story", "deliver", id, "-m", "Ready")).To(Equal(1))
+		Expect(stderr.String()).To(ContainSubstring("chore"))
+
+		delete(env, "TRACKER_PROJECT")
+		feature := server.AddStory(project.ID, tracker.Story{Name: "Tractor beam", State: tracker.StoryStateFinished, Estimate: tracker.Estimate(1)})
+		Expect(runTracker("story", "deliver", strconv.Itoa(feature.ID), "-m", "Ready")).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`State\s+delivered`))
+		Expect(server.Comments(project.ID, feature.ID)[0].Text).To(Equal("Ready"))
+	})
+
+	It("adds tasks and comments to stories in any project", func() {
+		story := server.AddStory(project.ID, tracker.Story{Name: "Superlaser"})
+		id := strconv.Itoa(story.ID)
+
+		Expect(runTracker("task", "add", id, "Find kyber crystals")).To(Equal(0))
+		Expect(server.Tasks(project.ID, story.ID)[0].Description).To(Equal("Find kyber crystals"))
+
+		Expect(runTracker("comment", "add", id, "Most impressive", "--json")).To(Equal(0))
+		var comment tracker.Comment
+		Expect(json.Unmarshal(stdout.Bytes(), &comment)).To(Succeed())
+		Expect(comment.Text).To(Equal("Most impressive"))
+
+		Expect(runTracker("story", "show", id)).To(Equal(0))
+		Expect(stdout.String()).To(MatchRegexp(`Name\s+Superlaser`))
+	})
+
+	It("explains how it is used", func() {
+		Expect(runTracker("story", "show")).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring("tracker story show takes ID"))
+		Expect(stderr.String()).To(ContainSubstring("usage: tracker"))
+
+		Expect(runTracker("launch")).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring(`unknown command "launch"`))
+	})
+})
@@ -0,0 +1,74 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+type config struct {
+	Token     string `json:"token"`
+	ProjectID int    `json:"project_id"`
+	URL       string `json:"url,omitempty"`
+}
+
+// loadConfig reads the config file, if there is one, and lays the
+// environment over it.
+func loadConfig(getenv func(string) string) (config, error) {
+	var conf config
+
+	path := getenv("TRACKER_CONFIG")
+	explicit := path != ""
+	if home := getenv("HOME"); !explicit && home != "" {
+		path = filepath.Join(home, ".config", "tracker", "config.json")
+	}
+
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &conf); err != nil {
+				return config{}, fmt.Errorf("invalid config file %s: %s", path, err)
+			}
+		case explicit || !os.IsNotExist(err):
+			return config{}, fmt.Errorf("failed to read config file: %s", err)
+		}
+	}
+
+	if token := getenv("TRACKER_TOKEN"); token != "" {
+		conf.Token = token
+	}
+
+	if project := getenv("TRACKER_PROJECT"); project != "" {
+		id, err := strconv.Atoi(project)
+		if err != nil {
+			return config{}, fmt.Errorf("invalid TRACKER_PROJECT %q", project)
+		}
+		conf.ProjectID = id
+	}
+
+	if url := getenv("TRACKER_URL"); url != "" {
+		conf.URL = url
+	}
+
+	return conf, nil
+}
+
+var errNoToken = errors.New("no API token: set TRACKER_TOKEN, pass --token or add one to the config file")
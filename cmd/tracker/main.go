@@ -0,0 +1,41 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command tracker works with Pivotal Tracker from the command line.
+//
+//	tracker me
+//	tracker stories list --state started --label foo
+//	tracker story show 560
+//	tracker story create --name "Build the superlaser" --type feature
+//	tracker story deliver 560 -m "Ready for review"
+//	tracker task add 560 "Find kyber crystals"
+//	tracker comment add 560 "Most impressive"
+//
+// The API token is read from --token, $TRACKER_TOKEN or the config file, in
+// that order, and likewise the project from --project, $TRACKER_PROJECT or
+// the config file. The config file is $TRACKER_CONFIG, or
+// ~/.config/tracker/config.json if that is unset:
+//
+//	{"token": "...", "project_id": 99}
+//
+// Every command prints a table, or JSON when given --json.
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Getenv, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,108 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+type output struct {
+	w    io.Writer
+	json bool
+}
+
+func (o output) print(value interface{}, table func(w io.Writer)) error {
+	if o.json {
+		encoder := json.NewEncoder(o.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	w := tabwriter.NewWriter(o.w, 0, 4, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+func (o output) me(me tracker.Me) error {
+	return o.print(me, func(w io.Writer) {
+		fmt.Fprintf(w, "ID\t%d\n", me.ID)
+		fmt.Fprintf(w, "Username\t%s\n", me.Username)
+		fmt.Fprintf(w, "Name\t%s\n", me.Name)
+		fmt.Fprintf(w, "Email\t%s\n", me.Email)
+	})
+}
+
+func (o output) stories(stories []tracker.Story) error {
+	if stories == nil {
+		stories = []tracker.Story{}
+	}
+
+	return o.print(stories, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tTYPE\tSTATE\tESTIMATE\tNAME")
+		for _, story := range stories {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", story.ID, story.Type, story.State, estimate(story), story.Name)
+		}
+	})
+}
+
+func (o output) story(story tracker.Story) error {
+	return o.print(story, func(w io.Writer) {
+		fmt.Fprintf(w, "ID\t%d\n", story.ID)
+		fmt.Fprintf(w, "Name\t%s\n", story.Name)
+		fmt.Fprintf(w, "Type\t%s\n", story.Type)
+		fmt.Fprintf(w, "State\t%s\n", story.State)
+		fmt.Fprintf(w, "Estimate\t%s\n", estimate(story))
+		fmt.Fprintf(w, "Labels\t%s\n", labels(story))
+		fmt.Fprintf(w, "URL\t%s\n", story.URL)
+		if story.Description != "" {
+			fmt.Fprintf(w, "\n%s\n", story.Description)
+		}
+	})
+}
+
+func (o output) task(task tracker.Task) error {
+	return o.print(task, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tSTORY\tPOSITION\tDESCRIPTION")
+		fmt.Fprintf(w, "%d\t%d\t%d\t%s\n", task.ID, task.StoryID, task.Position, task.Description)
+	})
+}
+
+func (o output) comment(comment tracker.Comment) error {
+	return o.print(comment, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tSTORY\tTEXT")
+		fmt.Fprintf(w, "%d\t%d\t%s\n", comment.ID, comment.StoryID, comment.Text)
+	})
+}
+
+func estimate(story tracker.Story) string {
+	if story.Estimate == nil {
+		return "-"
+	}
+	return strconv.Itoa(*story.Estimate)
+}
+
+func labels(story tracker.Story) string {
+	names := make([]string, len(story.Labels))
+	for i, label := range story.Labels {
+		names[i] = label.Name
+	}
+	return strings.Join(names, ", ")
+}
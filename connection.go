@@ -14,20 +14,56 @@
 package tracker
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
+	"time"
 )
 
 type connection struct {
-	token  string
-	client *http.Client
+	token      string
+	baseURL    string
+	client     *http.Client
+	middleware []Middleware
+
+	logger   *slog.Logger
+	logLevel slog.Level
+
+	telemetry *Telemetry
+
+	cache    CacheStore
+	cacheTTL time.Duration
+
+	strict bool
+
+	// operation names the client method making requests, for telemetry.
+	operation string
+}
+
+// Doer sends an HTTP request. *http.Client is a Doer.
+type Doer interface {
+	Do(request *http.Request) (*http.Response, error)
 }
 
+// DoerFunc lets an ordinary function be used as a Doer.
+type DoerFunc func(request *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Middleware wraps the Doer that sends every request the client makes, to
+// inspect or change requests and responses on their way through.
+type Middleware func(next Doer) Doer
+
 func newConnection(token string) connection {
 	return connection{
 		token:  token,
@@ -35,6 +71,15 @@ func newConnection(token string) connection {
 	}
 }
 
+// named returns a copy of the connection whose requests are made for the
+// operation, unless an outer client method has named them already.
+func (c connection) named(operation string) connection {
+	if c.operation == "" {
+		c.operation = operation
+	}
+	return c
+}
+
 type Pagination struct {
 	Total    int
 	Offset   int
@@ -47,10 +92,36 @@ const paginationOffsetHeader = "X-Tracker-Pagination-Offset"
 const paginationLimitHeader = "X-Tracker-Pagination-Limit"
 const paginationReturnedHeader = "X-Tracker-Pagination-Returned"
 
+// projectVersionHeader carries the project's version on responses, and the
+// version a write expects the project to be at on requests.
+const projectVersionHeader = "X-Tracker-Project-Version"
+
+// ConflictError is returned when Tracker refuses a write because the
+// project has changed since the version the write expected.
+type ConflictError struct {
+	ProjectVersion int
+	Code           string
+	Message        string
+}
+
+func (e ConflictError) Error() string {
+	if e.Message == "" {
+		return "request conflicted with a newer version of the project"
+	}
+	return fmt.Sprintf("request conflicted with a newer version of the project (%s): %s", e.Code, e.Message)
+}
+
 func (c connection) Do(request *http.Request, response interface{}) (Pagination, error) {
+	pagination, _, err := c.DoWithVersion(request, response)
+	return pagination, err
+}
+
+// DoWithVersion is like Do but also returns the project version reported
+// by Tracker, or zero if there was none.
+func (c connection) DoWithVersion(request *http.Request, response interface{}) (Pagination, int, error) {
 	resp, err := c.sendRequest(request)
 	if err != nil {
-		return Pagination{}, err
+		return Pagination{}, 0, err
 	}
 
 	defer resp.Body.Close()
@@ -60,40 +131,53 @@ func (c connection) Do(request *http.Request, response interface{}) (Pagination,
 	if val := resp.Header.Get(paginationTotalHeader); len(val) > 0 {
 		pagination.Total, err = strconv.Atoi(val)
 		if err != nil {
-			return Pagination{}, err
+			return Pagination{}, 0, err
 		}
 	}
 
 	if val := resp.Header.Get(paginationOffsetHeader); len(val) > 0 {
 		pagination.Offset, err = strconv.Atoi(val)
 		if err != nil {
-			return Pagination{}, err
+			return Pagination{}, 0, err
 		}
 	}
 
 	if val := resp.Header.Get(paginationLimitHeader); len(val) > 0 {
 		pagination.Limit, err = strconv.Atoi(val)
 		if err != nil {
-			return Pagination{}, err
+			return Pagination{}, 0, err
 		}
 	}
 
 	if val := resp.Header.Get(paginationReturnedHeader); len(val) > 0 {
 		pagination.Returned, err = strconv.Atoi(val)
 		if err != nil {
-			return Pagination{}, err
+			return Pagination{}, 0, err
+		}
+	}
+
+	version := 0
+	if val := resp.Header.Get(projectVersionHeader); len(val) > 0 {
+		version, err = strconv.Atoi(val)
+		if err != nil {
+			return Pagination{}, 0, err
 		}
 	}
 
 	if response != nil {
-		return pagination, c.decodeResponse(resp, response)
+		return pagination, version, c.decodeResponse(resp, response)
 	}
 
-	return pagination, nil
+	return pagination, version, nil
 }
 
 func (c connection) CreateRequest(method string, path string, params url.Values) (*http.Request, error) {
-	url := DefaultURL + "/services/v5" + path
+	baseURL := c.baseURL
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+
+	url := baseURL + "/services/v5" + path
 	query := params.Encode()
 	if query != "" {
 		url += "?" + query
@@ -106,11 +190,41 @@ func (c connection) CreateRequest(method string, path string, params url.Values)
 
 	request.Header.Add("X-TrackerToken", c.token)
 
+	if c.operation != "" {
+		request = request.WithContext(withOperation(request.Context(), c.operation))
+	}
+
 	return request, nil
 }
 
+func (c connection) AddJSONBodyReader(request *http.Request, body io.Reader) {
+	request.Header.Add("Content-Type", "application/json")
+	request.Body = ioutil.NopCloser(body)
+}
+
+// doer chains the middleware around the HTTP client, the first added
+// being the outermost. Logging sits innermost so that it sees requests as
+// they are sent, with the cache just outside it so that revalidations are
+// logged, and telemetry outermost so that middleware can see spans.
+func (c connection) doer() Doer {
+	var doer Doer = c.client
+	if c.logger != nil {
+		doer = loggingDoer{next: doer, logger: c.logger, level: c.logLevel}
+	}
+	if c.cache != nil {
+		doer = cacheDoer{next: doer, store: c.cache, ttl: c.cacheTTL}
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		doer = c.middleware[i](doer)
+	}
+	if c.telemetry != nil {
+		doer = telemetryDoer{next: doer, telemetry: *c.telemetry}
+	}
+	return doer
+}
+
 func (c connection) sendRequest(request *http.Request) (*http.Response, error) {
-	response, err := c.client.Do(request)
+	response, err := c.doer().Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %s", err)
 	}
@@ -119,18 +233,50 @@ func (c connection) sendRequest(request *http.Request) (*http.Response, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	if response.StatusCode == http.StatusConflict {
+		return nil, newConflictError(response)
+	}
+
 	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusNoContent {
 		d, _ := ioutil.ReadAll(response.Body)
-		return nil, fmt.Errorf("request failed (%s): %s", response.Status, string(d))
+		response.Body.Close()
+		if len(bytes.TrimSpace(d)) == 0 {
+			return nil, fmt.Errorf("request failed (%d)", response.StatusCode)
+		}
+		return nil, fmt.Errorf("request failed (%d): %s", response.StatusCode, string(d))
 	}
 
 	return response, nil
 }
 
+func newConflictError(response *http.Response) error {
+	defer response.Body.Close()
+
+	conflict := ConflictError{}
+	conflict.ProjectVersion, _ = strconv.Atoi(response.Header.Get(projectVersionHeader))
+
+	var body struct {
+		Code  string `json:"code"`
+		Error string `json:"error"`
+	}
+	if json.NewDecoder(response.Body).Decode(&body) == nil {
+		conflict.Code = body.Code
+		conflict.Message = body.Error
+	}
+
+	return conflict
+}
+
 func (c connection) decodeResponse(response *http.Response, object interface{}) error {
 	if err := json.NewDecoder(response.Body).Decode(object); err != nil {
 		return fmt.Errorf("invalid json response: %s", err)
 	}
 
+	if c.strict {
+		if err := checkKnownValues(reflect.ValueOf(object)); err != nil {
+			return fmt.Errorf("invalid json response: %s", err)
+		}
+	}
+
 	return response.Body.Close()
 }
@@ -0,0 +1,165 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const searchDateFormat = "01/02/2006"
+
+// SearchFilter builds a query in Tracker's search syntax. Each method
+// returns a new filter with the extra term, leaving the original untouched,
+// and all the terms must match. Use it as the Filter of a StoriesQuery:
+//
+//	query := StoriesQuery{
+//		Filter: Filter().Label("death star").Owner("vader").Terms(),
+//	}
+type SearchFilter struct {
+	terms []string
+}
+
+func Filter() SearchFilter {
+	return SearchFilter{}
+}
+
+// Term adds a "key:value" term, quoting the value if needed.
+func (f SearchFilter) Term(key string, value string) SearchFilter {
+	return f.with(key + ":" + quoteSearchValue(value))
+}
+
+// Text adds free text which must appear in the story.
+func (f SearchFilter) Text(text string) SearchFilter {
+	return f.with(quoteSearchValue(text))
+}
+
+// State matches stories in any of the given states.
+func (f SearchFilter) State(states ...StoryState) SearchFilter {
+	var alternatives []SearchFilter
+	for _, state := range states {
+		alternatives = append(alternatives, Filter().Term("state", string(state)))
+	}
+	return f.Or(alternatives...)
+}
+
+func (f SearchFilter) Type(storyType StoryType) SearchFilter {
+	return f.Term("type", string(storyType))
+}
+
+func (f SearchFilter) Label(label string) SearchFilter {
+	return f.Term("label", label)
+}
+
+func (f SearchFilter) Owner(owner string) SearchFilter {
+	return f.Term("owner", owner)
+}
+
+func (f SearchFilter) Requester(requester string) SearchFilter {
+	return f.Term("requester", requester)
+}
+
+// ID matches stories with any of the given IDs.
+func (f SearchFilter) ID(ids ...int) SearchFilter {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(id))
+	}
+	return f.with("id:" + strings.Join(values, ","))
+}
+
+func (f SearchFilter) CreatedSince(t time.Time) SearchFilter {
+	return f.Term("created_since", t.Format(searchDateFormat))
+}
+
+func (f SearchFilter) UpdatedSince(t time.Time) SearchFilter {
+	return f.Term("updated_since", t.Format(searchDateFormat))
+}
+
+func (f SearchFilter) AcceptedSince(t time.Time) SearchFilter {
+	return f.Term("accepted_since", t.Format(searchDateFormat))
+}
+
+func (f SearchFilter) AcceptedBefore(t time.Time) SearchFilter {
+	return f.Term("accepted_before", t.Format(searchDateFormat))
+}
+
+// IncludeDone makes the search include accepted stories from past
+// iterations, which Tracker leaves out by default.
+func (f SearchFilter) IncludeDone() SearchFilter {
+	return f.with("includedone:true")
+}
+
+// Not matches stories which do not match the other filter.
+func (f SearchFilter) Not(other SearchFilter) SearchFilter {
+	if len(other.terms) == 0 {
+		return f
+	}
+	return f.with("-" + other.group())
+}
+
+// Or matches stories which match any of the other filters.
+func (f SearchFilter) Or(others ...SearchFilter) SearchFilter {
+	var alternatives []string
+	for _, other := range others {
+		if len(other.terms) != 0 {
+			alternatives = append(alternatives, other.group())
+		}
+	}
+
+	switch len(alternatives) {
+	case 0:
+		return f
+	case 1:
+		return f.with(alternatives[0])
+	default:
+		return f.with("(" + strings.Join(alternatives, " OR ") + ")")
+	}
+}
+
+// Terms returns the terms of the filter, in the form taken by
+// StoriesQuery.Filter.
+func (f SearchFilter) Terms() []string {
+	terms := make([]string, len(f.terms))
+	copy(terms, f.terms)
+	return terms
+}
+
+func (f SearchFilter) String() string {
+	return strings.Join(f.terms, " ")
+}
+
+func (f SearchFilter) with(term string) SearchFilter {
+	terms := make([]string, len(f.terms), len(f.terms)+1)
+	copy(terms, f.terms)
+	return SearchFilter{terms: append(terms, term)}
+}
+
+// group returns the filter as a single term.
+func (f SearchFilter) group() string {
+	if len(f.terms) == 1 {
+		return f.terms[0]
+	}
+	return "(" + f.String() + ")"
+}
+
+func quoteSearchValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t:(),\"\\") && !strings.HasPrefix(value, "-") {
+		return value
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + escaped + `"`
+}
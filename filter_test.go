@@ -0,0 +1,92 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker_test
+
+import (
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SearchFilter", func() {
+	It("is empty by default", func() {
+		Expect(tracker.Filter().String()).To(Equal(""))
+		Expect(tracker.Filter().Terms()).To(BeEmpty())
+	})
+
+	It("joins terms with spaces so that all of them must match", func() {
+		filter := tracker.Filter().
+			Owner("vader").
+			Type(tracker.StoryTypeBug).
+			UpdatedSince(time.Date(2015, 7, 4, 12, 0, 0, 0, time.UTC))
+
+		Expect(filter.String()).To(Equal("owner:vader type:bug updated_since:07/04/2015"))
+	})
+
+	It("quotes values which contain spaces or search syntax", func() {
+		Expect(tracker.Filter().Label("rebel bases").String()).To(Equal(`label:"rebel bases"`))
+		Expect(tracker.Filter().Label("plans:stolen").String()).To(Equal(`label:"plans:stolen"`))
+		Expect(tracker.Filter().Label(`the "ultimate" weapon`).String()).To(Equal(`label:"the \"ultimate\" weapon"`))
+		Expect(tracker.Filter().Label("-negative").String()).To(Equal(`label:"-negative"`))
+		Expect(tracker.Filter().Label("").String()).To(Equal(`label:""`))
+		Expect(tracker.Filter().Text("tractor beam").String()).To(Equal(`"tractor beam"`))
+	})
+
+	It("matches any of several states", func() {
+		Expect(tracker.Filter().State(tracker.StoryStateStarted).String()).To(Equal("state:started"))
+		Expect(tracker.Filter().State(tracker.StoryStateStarted, tracker.StoryStateFinished).String()).
+			To(Equal("(state:started OR state:finished)"))
+	})
+
+	It("negates terms", func() {
+		filter := tracker.Filter().
+			Not(tracker.Filter().Label("blocked")).
+			Not(tracker.Filter().Owner("vader").Type(tracker.StoryTypeChore))
+
+		Expect(filter.String()).To(Equal("-label:blocked -(owner:vader type:chore)"))
+	})
+
+	It("matches any of several filters", func() {
+		filter := tracker.Filter().Or(
+			tracker.Filter().Owner("vader"),
+			tracker.Filter().Owner("tarkin").Label("death star"),
+		).IncludeDone()
+
+		Expect(filter.String()).To(Equal(`(owner:vader OR (owner:tarkin label:"death star")) includedone:true`))
+	})
+
+	It("matches any of several IDs", func() {
+		Expect(tracker.Filter().ID(560, 561).String()).To(Equal("id:560,561"))
+	})
+
+	It("does not change the filter it was built from", func() {
+		base := tracker.Filter().Owner("vader")
+		bugs := base.Type(tracker.StoryTypeBug)
+		chores := base.Type(tracker.StoryTypeChore)
+
+		Expect(base.String()).To(Equal("owner:vader"))
+		Expect(bugs.String()).To(Equal("owner:vader type:bug"))
+		Expect(chores.String()).To(Equal("owner:vader type:chore"))
+	})
+
+	It("can be used as the filter of a stories query", func() {
+		query := tracker.StoriesQuery{
+			Filter: tracker.Filter().Label("rebel bases").ID(560).Terms(),
+		}
+
+		Expect(query.Query().Encode()).To(Equal("filter=label%3A%22rebel+bases%22+id%3A560"))
+	})
+})
@@ -0,0 +1,162 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+type retryKey struct{}
+
+// withRetry marks the requests made with ctx as the nth retry of an
+// operation.
+func withRetry(ctx context.Context, retry int) context.Context {
+	return context.WithValue(ctx, retryKey{}, retry)
+}
+
+func retryFromContext(ctx context.Context) int {
+	retry, _ := ctx.Value(retryKey{}).(int)
+	return retry
+}
+
+type loggingDoer struct {
+	next   Doer
+	logger *slog.Logger
+	level  slog.Level
+}
+
+func (d loggingDoer) Do(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := d.next.Do(request)
+	duration := time.Since(start)
+
+	ctx := request.Context()
+	level := d.level
+
+	attrs := []slog.Attr{
+		slog.String("method", request.Method),
+		slog.String("path", request.URL.Path),
+	}
+	if request.URL.RawQuery != "" {
+		attrs = append(attrs, slog.String("query", request.URL.RawQuery))
+	}
+
+	if err != nil {
+		level = maxLevel(level, slog.LevelWarn)
+		attrs = append(attrs, slog.Duration("duration", duration), slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.Int("status", response.StatusCode), slog.Duration("duration", duration))
+
+		if pagination := paginationAttrs(response.Header); len(pagination) > 0 {
+			attrs = append(attrs, slog.Attr{Key: "pagination", Value: slog.GroupValue(pagination...)})
+		}
+
+		if response.StatusCode >= http.StatusBadRequest {
+			level = maxLevel(level, slog.LevelWarn)
+			attrs = append(attrs, errorAttrs(response)...)
+		}
+	}
+
+	if retry := retryFromContext(ctx); retry > 0 {
+		attrs = append(attrs, slog.Int("retry", retry))
+	}
+
+	if d.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Attr{Key: "request_header", Value: headerValue(request.Header)})
+	}
+
+	d.logger.LogAttrs(ctx, level, "tracker request", attrs...)
+
+	return response, err
+}
+
+func paginationAttrs(header http.Header) []slog.Attr {
+	var attrs []slog.Attr
+	for _, field := range []struct {
+		key    string
+		header string
+	}{
+		{"total", paginationTotalHeader},
+		{"offset", paginationOffsetHeader},
+		{"limit", paginationLimitHeader},
+		{"returned", paginationReturnedHeader},
+	} {
+		if value, err := strconv.Atoi(header.Get(field.header)); err == nil {
+			attrs = append(attrs, slog.Int(field.key, value))
+		}
+	}
+	return attrs
+}
+
+func errorAttrs(response *http.Response) []slog.Attr {
+	code, message := readTrackerError(response)
+	if code == "" {
+		return nil
+	}
+
+	return []slog.Attr{
+		slog.String("error_code", code),
+		slog.String("error", message),
+	}
+}
+
+// readTrackerError reads Tracker's error code and message from a failed
+// response, leaving the body in place for the client to read again.
+func readTrackerError(response *http.Response) (string, string) {
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return "", ""
+	}
+
+	var trackerError struct {
+		Code  string `json:"code"`
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &trackerError) != nil {
+		return "", ""
+	}
+
+	return trackerError.Code, trackerError.Error
+}
+
+func headerValue(header http.Header) slog.Value {
+	var attrs []slog.Attr
+	for key, values := range header {
+		value := strings.Join(values, ", ")
+		if http.CanonicalHeaderKey(key) == "X-Trackertoken" {
+			value = redacted
+		}
+		attrs = append(attrs, slog.String(key, value))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func maxLevel(a slog.Level, b slog.Level) slog.Level {
+	if a > b {
+		return a
+	}
+	return b
+}
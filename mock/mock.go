@@ -0,0 +1,587 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package mock
+
+import (
+	"github.com/deoxxa/go-tracker"
+)
+
+var (
+	_ tracker.ProjectAPI = &ProjectClient{}
+	_ tracker.AccountAPI = &AccountClient{}
+	_ tracker.ClientAPI  = &Client{}
+)
+
+// ProjectClient is a stand-in for tracker.ProjectClient. Each method calls
+// the function in the matching field, or fails with an UnexpectedCallError
+// if the field is nil.
+type ProjectClient struct {
+	Recorder
+
+	ProjectFunc     func() (*tracker.Project, error)
+	LabelsFunc      func(tracker.LabelsQuery) ([]tracker.Label, tracker.Pagination, error)
+	IterationsFunc  func(tracker.IterationsQuery) ([]tracker.Iteration, tracker.Pagination, error)
+	SearchFunc      func(tracker.SearchQuery) (tracker.SearchResults, error)
+	TransitionsFunc func(tracker.StoryTransitionsQuery) ([]tracker.StoryTransition, tracker.Pagination, error)
+
+	StoryFunc                   func(int) (tracker.Story, error)
+	StoryWithQueryFunc          func(int, tracker.StoryQuery) (tracker.Story, error)
+	StoryWithVersionFunc        func(int) (tracker.Story, int, error)
+	StoriesFunc                 func(tracker.StoriesQuery) ([]tracker.Story, tracker.Pagination, error)
+	StoriesByIDsFunc            func([]int) ([]tracker.Story, error)
+	StoryActivityFunc           func(int, tracker.ActivityQuery) ([]tracker.Activity, error)
+	StoryTransitionsFunc        func(int) ([]tracker.StoryTransition, error)
+	CreateStoryFunc             func(tracker.NewStory) (tracker.Story, error)
+	UpdateStoryFunc             func(tracker.Story) (tracker.Story, error)
+	UpdateStoryLabelsFunc       func(int, []string) (tracker.Story, error)
+	ModifyStoryFunc             func(int, func(*tracker.Story) error) (tracker.Story, error)
+	DeleteStoryFunc             func(int) error
+	MoveStoryFunc               func(int, tracker.StoryPosition) (tracker.Story, error)
+	MoveStoriesFunc             func([]int, tracker.StoryPosition) ([]tracker.Story, error)
+	MoveStoryToProjectFunc      func(int, int) (tracker.Story, error)
+	StartStoryFunc              func(int) (tracker.Story, error)
+	FinishStoryFunc             func(int) (tracker.Story, error)
+	DeliverStoryFunc            func(int) error
+	DeliverStoryWithCommentFunc func(int, string) error
+	AcceptStoryFunc             func(int) (tracker.Story, error)
+	RejectStoryFunc             func(int, string) (tracker.Story, error)
+	TransitionStoryFunc         func(int, tracker.StoryState) (tracker.Story, error)
+	CreateBlockerFunc           func(int, tracker.Blocker) (tracker.Blocker, error)
+	BulkCreateStoriesFunc       func([]tracker.NewStory, tracker.BulkOptions) ([]tracker.BulkResult, error)
+	BulkUpdateStoriesFunc       func([]tracker.Story, tracker.BulkOptions) ([]tracker.BulkResult, error)
+	BulkDeleteStoriesFunc       func([]int, tracker.BulkOptions) ([]tracker.BulkResult, error)
+
+	StoryTasksFunc func(int, tracker.TaskQuery) ([]tracker.Task, error)
+	CreateTaskFunc func(int, tracker.Task) (tracker.Task, error)
+
+	StoryCommentsFunc func(int, tracker.CommentsQuery) ([]tracker.Comment, error)
+	CreateCommentFunc func(int, tracker.Comment) (tracker.Comment, error)
+
+	ProjectMembershipsFunc func() ([]tracker.ProjectMembership, error)
+	AddMemberFunc          func(tracker.NewProjectMembership) (tracker.ProjectMembership, error)
+	UpdateMemberRoleFunc   func(int, tracker.ProjectRole) (tracker.ProjectMembership, error)
+	RemoveMemberFunc       func(int) error
+}
+
+// Client is a stand-in for tracker.Client, as a tracker.ClientAPI. InProject
+// and InAccount return a mock with no functions set if their field is nil.
+type Client struct {
+	Recorder
+
+	MeFunc       func() (tracker.Me, error)
+	AccountsFunc func() ([]tracker.Account, error)
+	AccountFunc  func(int) (tracker.Account, error)
+
+	WorkspacesFunc           func() ([]tracker.Workspace, error)
+	WorkspaceFunc            func(int) (tracker.Workspace, error)
+	CreateWorkspaceFunc      func(tracker.Workspace) (tracker.Workspace, error)
+	UpdateWorkspaceFunc      func(tracker.Workspace) (tracker.Workspace, error)
+	SetWorkspaceProjectsFunc func(int, []int) (tracker.Workspace, error)
+	DeleteWorkspaceFunc      func(int) error
+
+	StoryFunc          func(int) (tracker.Story, error)
+	StoryWithQueryFunc func(int, tracker.StoryQuery) (tracker.Story, error)
+	StoriesByIDsFunc   func([]int) map[int]tracker.StoryResult
+
+	InProjectFunc   func(int) tracker.ProjectAPI
+	InAccountFunc   func(int) tracker.AccountAPI
+	InWorkspaceFunc func(int) ([]tracker.ProjectAPI, error)
+}
+
+// AccountClient is a stand-in for tracker.AccountClient.
+type AccountClient struct {
+	Recorder
+
+	MembershipsFunc  func() ([]tracker.AccountMembership, error)
+	AddMemberFunc    func(tracker.NewAccountMembership) (tracker.AccountMembership, error)
+	UpdateMemberFunc func(int, tracker.AccountPermissions) (tracker.AccountMembership, error)
+	RemoveMemberFunc func(int) error
+	ProjectsFunc     func() ([]tracker.Project, error)
+}
+
+func (m *ProjectClient) Project() (*tracker.Project, error) {
+	m.record("Project")
+	if m.ProjectFunc == nil {
+		return nil, m.unexpected("Project")
+	}
+	return m.ProjectFunc()
+}
+
+func (m *ProjectClient) Labels(query tracker.LabelsQuery) ([]tracker.Label, tracker.Pagination, error) {
+	m.record("Labels", query)
+	if m.LabelsFunc == nil {
+		return nil, tracker.Pagination{}, m.unexpected("Labels")
+	}
+	return m.LabelsFunc(query)
+}
+
+func (m *ProjectClient) Iterations(query tracker.IterationsQuery) ([]tracker.Iteration, tracker.Pagination, error) {
+	m.record("Iterations", query)
+	if m.IterationsFunc == nil {
+		return nil, tracker.Pagination{}, m.unexpected("Iterations")
+	}
+	return m.IterationsFunc(query)
+}
+
+func (m *ProjectClient) Search(query tracker.SearchQuery) (tracker.SearchResults, error) {
+	m.record("Search", query)
+	if m.SearchFunc == nil {
+		return tracker.SearchResults{}, m.unexpected("Search")
+	}
+	return m.SearchFunc(query)
+}
+
+func (m *ProjectClient) Transitions(query tracker.StoryTransitionsQuery) ([]tracker.StoryTransition, tracker.Pagination, error) {
+	m.record("Transitions", query)
+	if m.TransitionsFunc == nil {
+		return nil, tracker.Pagination{}, m.unexpected("Transitions")
+	}
+	return m.TransitionsFunc(query)
+}
+
+func (m *ProjectClient) Story(storyID int) (tracker.Story, error) {
+	m.record("Story", storyID)
+	if m.StoryFunc == nil {
+		return tracker.Story{}, m.unexpected("Story")
+	}
+	return m.StoryFunc(storyID)
+}
+
+func (m *ProjectClient) StoryWithQuery(storyID int, query tracker.StoryQuery) (tracker.Story, error) {
+	m.record("StoryWithQuery", storyID, query)
+	if m.StoryWithQueryFunc == nil {
+		return tracker.Story{}, m.unexpected("StoryWithQuery")
+	}
+	return m.StoryWithQueryFunc(storyID, query)
+}
+
+func (m *ProjectClient) StoryWithVersion(storyID int) (tracker.Story, int, error) {
+	m.record("StoryWithVersion", storyID)
+	if m.StoryWithVersionFunc == nil {
+		return tracker.Story{}, 0, m.unexpected("StoryWithVersion")
+	}
+	return m.StoryWithVersionFunc(storyID)
+}
+
+func (m *ProjectClient) Stories(query tracker.StoriesQuery) ([]tracker.Story, tracker.Pagination, error) {
+	m.record("Stories", query)
+	if m.StoriesFunc == nil {
+		return nil, tracker.Pagination{}, m.unexpected("Stories")
+	}
+	return m.StoriesFunc(query)
+}
+
+func (m *ProjectClient) StoriesByIDs(storyIDs []int) ([]tracker.Story, error) {
+	m.record("StoriesByIDs", storyIDs)
+	if m.StoriesByIDsFunc == nil {
+		return nil, m.unexpected("StoriesByIDs")
+	}
+	return m.StoriesByIDsFunc(storyIDs)
+}
+
+func (m *ProjectClient) StoryActivity(storyID int, query tracker.ActivityQuery) ([]tracker.Activity, error) {
+	m.record("StoryActivity", storyID, query)
+	if m.StoryActivityFunc == nil {
+		return nil, m.unexpected("StoryActivity")
+	}
+	return m.StoryActivityFunc(storyID, query)
+}
+
+func (m *ProjectClient) StoryTransitions(storyID int) ([]tracker.StoryTransition, error) {
+	m.record("StoryTransitions", storyID)
+	if m.StoryTransitionsFunc == nil {
+		return nil, m.unexpected("StoryTransitions")
+	}
+	return m.StoryTransitionsFunc(storyID)
+}
+
+func (m *ProjectClient) CreateStory(story tracker.NewStory) (tracker.Story, error) {
+	m.record("CreateStory", story)
+	if m.CreateStoryFunc == nil {
+		return tracker.Story{}, m.unexpected("CreateStory")
+	}
+	return m.CreateStoryFunc(story)
+}
+
+func (m *ProjectClient) UpdateStory(story tracker.Story) (tracker.Story, error) {
+	m.record("UpdateStory", story)
+	if m.UpdateStoryFunc == nil {
+		return tracker.Story{}, m.unexpected("UpdateStory")
+	}
+	return m.UpdateStoryFunc(story)
+}
+
+func (m *ProjectClient) UpdateStoryLabels(storyID int, labels []string) (tracker.Story, error) {
+	m.record("UpdateStoryLabels", storyID, labels)
+	if m.UpdateStoryLabelsFunc == nil {
+		return tracker.Story{}, m.unexpected("UpdateStoryLabels")
+	}
+	return m.UpdateStoryLabelsFunc(storyID, labels)
+}
+
+func (m *ProjectClient) ModifyStory(storyID int, modify func(*tracker.Story) error) (tracker.Story, error) {
+	m.record("ModifyStory", storyID, modify)
+	if m.ModifyStoryFunc == nil {
+		return tracker.Story{}, m.unexpected("ModifyStory")
+	}
+	return m.ModifyStoryFunc(storyID, modify)
+}
+
+func (m *ProjectClient) DeleteStory(storyID int) error {
+	m.record("DeleteStory", storyID)
+	if m.DeleteStoryFunc == nil {
+		return m.unexpected("DeleteStory")
+	}
+	return m.DeleteStoryFunc(storyID)
+}
+
+func (m *ProjectClient) MoveStory(storyID int, position tracker.StoryPosition) (tracker.Story, error) {
+	m.record("MoveStory", storyID, position)
+	if m.MoveStoryFunc == nil {
+		return tracker.Story{}, m.unexpected("MoveStory")
+	}
+	return m.MoveStoryFunc(storyID, position)
+}
+
+func (m *ProjectClient) MoveStories(storyIDs []int, position tracker.StoryPosition) ([]tracker.Story, error) {
+	m.record("MoveStories", storyIDs, position)
+	if m.MoveStoriesFunc == nil {
+		return nil, m.unexpected("MoveStories")
+	}
+	return m.MoveStoriesFunc(storyIDs, position)
+}
+
+func (m *ProjectClient) MoveStoryToProject(storyID int, projectID int) (tracker.Story, error) {
+	m.record("MoveStoryToProject", storyID, projectID)
+	if m.MoveStoryToProjectFunc == nil {
+		return tracker.Story{}, m.unexpected("MoveStoryToProject")
+	}
+	return m.MoveStoryToProjectFunc(storyID, projectID)
+}
+
+func (m *ProjectClient) StartStory(storyID int) (tracker.Story, error) {
+	m.record("StartStory", storyID)
+	if m.StartStoryFunc == nil {
+		return tracker.Story{}, m.unexpected("StartStory")
+	}
+	return m.StartStoryFunc(storyID)
+}
+
+func (m *ProjectClient) FinishStory(storyID int) (tracker.Story, error) {
+	m.record("FinishStory", storyID)
+	if m.FinishStoryFunc == nil {
+		return tracker.Story{}, m.unexpected("FinishStory")
+	}
+	return m.FinishStoryFunc(storyID)
+}
+
+func (m *ProjectClient) DeliverStory(storyID int) error {
+	m.record("DeliverStory", storyID)
+	if m.DeliverStoryFunc == nil {
+		return m.unexpected("DeliverStory")
+	}
+	return m.DeliverStoryFunc(storyID)
+}
+
+func (m *ProjectClient) DeliverStoryWithComment(storyID int, comment string) error {
+	m.record("DeliverStoryWithComment", storyID, comment)
+	if m.DeliverStoryWithCommentFunc == nil {
+		return m.unexpected("DeliverStoryWithComment")
+	}
+	return m.DeliverStoryWithCommentFunc(storyID, comment)
+}
+
+func (m *ProjectClient) AcceptStory(storyID int) (tracker.Story, error) {
+	m.record("AcceptStory", storyID)
+	if m.AcceptStoryFunc == nil {
+		return tracker.Story{}, m.unexpected("AcceptStory")
+	}
+	return m.AcceptStoryFunc(storyID)
+}
+
+func (m *ProjectClient) RejectStory(storyID int, reason string) (tracker.Story, error) {
+	m.record("RejectStory", storyID, reason)
+	if m.RejectStoryFunc == nil {
+		return tracker.Story{}, m.unexpected("RejectStory")
+	}
+	return m.RejectStoryFunc(storyID, reason)
+}
+
+func (m *ProjectClient) TransitionStory(storyID int, state tracker.StoryState) (tracker.Story, error) {
+	m.record("TransitionStory", storyID, state)
+	if m.TransitionStoryFunc == nil {
+		return tracker.Story{}, m.unexpected("TransitionStory")
+	}
+	return m.TransitionStoryFunc(storyID, state)
+}
+
+func (m *ProjectClient) CreateBlocker(storyID int, blocker tracker.Blocker) (tracker.Blocker, error) {
+	m.record("CreateBlocker", storyID, blocker)
+	if m.CreateBlockerFunc == nil {
+		return tracker.Blocker{}, m.unexpected("CreateBlocker")
+	}
+	return m.CreateBlockerFunc(storyID, blocker)
+}
+
+func (m *ProjectClient) BulkCreateStories(stories []tracker.NewStory, options tracker.BulkOptions) ([]tracker.BulkResult, error) {
+	m.record("BulkCreateStories", stories, options)
+	if m.BulkCreateStoriesFunc == nil {
+		return nil, m.unexpected("BulkCreateStories")
+	}
+	return m.BulkCreateStoriesFunc(stories, options)
+}
+
+func (m *ProjectClient) BulkUpdateStories(stories []tracker.Story, options tracker.BulkOptions) ([]tracker.BulkResult, error) {
+	m.record("BulkUpdateStories", stories, options)
+	if m.BulkUpdateStoriesFunc == nil {
+		return nil, m.unexpected("BulkUpdateStories")
+	}
+	return m.BulkUpdateStoriesFunc(stories, options)
+}
+
+func (m *ProjectClient) BulkDeleteStories(storyIDs []int, options tracker.BulkOptions) ([]tracker.BulkResult, error) {
+	m.record("BulkDeleteStories", storyIDs, options)
+	if m.BulkDeleteStoriesFunc == nil {
+		return nil, m.unexpected("BulkDeleteStories")
+	}
+	return m.BulkDeleteStoriesFunc(storyIDs, options)
+}
+
+func (m *ProjectClient) StoryTasks(storyID int, query tracker.TaskQuery) ([]tracker.Task, error) {
+	m.record("StoryTasks", storyID, query)
+	if m.StoryTasksFunc == nil {
+		return nil, m.unexpected("StoryTasks")
+	}
+	return m.StoryTasksFunc(storyID, query)
+}
+
+func (m *ProjectClient) CreateTask(storyID int, task tracker.Task) (tracker.Task, error) {
+	m.record("CreateTask", storyID, task)
+	if m.CreateTaskFunc == nil {
+		return tracker.Task{}, m.unexpected("CreateTask")
+	}
+	return m.CreateTaskFunc(storyID, task)
+}
+
+func (m *ProjectClient) StoryComments(storyID int, query tracker.CommentsQuery) ([]tracker.Comment, error) {
+	m.record("StoryComments", storyID, query)
+	if m.StoryCommentsFunc == nil {
+		return nil, m.unexpected("StoryComments")
+	}
+	return m.StoryCommentsFunc(storyID, query)
+}
+
+func (m *ProjectClient) CreateComment(storyID int, comment tracker.Comment) (tracker.Comment, error) {
+	m.record("CreateComment", storyID, comment)
+	if m.CreateCommentFunc == nil {
+		return tracker.Comment{}, m.unexpected("CreateComment")
+	}
+	return m.CreateCommentFunc(storyID, comment)
+}
+
+func (m *ProjectClient) ProjectMemberships() ([]tracker.ProjectMembership, error) {
+	m.record("ProjectMemberships")
+	if m.ProjectMembershipsFunc == nil {
+		return nil, m.unexpected("ProjectMemberships")
+	}
+	return m.ProjectMembershipsFunc()
+}
+
+func (m *ProjectClient) AddMember(membership tracker.NewProjectMembership) (tracker.ProjectMembership, error) {
+	m.record("AddMember", membership)
+	if m.AddMemberFunc == nil {
+		return tracker.ProjectMembership{}, m.unexpected("AddMember")
+	}
+	return m.AddMemberFunc(membership)
+}
+
+func (m *ProjectClient) UpdateMemberRole(membershipID int, role tracker.ProjectRole) (tracker.ProjectMembership, error) {
+	m.record("UpdateMemberRole", membershipID, role)
+	if m.UpdateMemberRoleFunc == nil {
+		return tracker.ProjectMembership{}, m.unexpected("UpdateMemberRole")
+	}
+	return m.UpdateMemberRoleFunc(membershipID, role)
+}
+
+func (m *ProjectClient) RemoveMember(membershipID int) error {
+	m.record("RemoveMember", membershipID)
+	if m.RemoveMemberFunc == nil {
+		return m.unexpected("RemoveMember")
+	}
+	return m.RemoveMemberFunc(membershipID)
+}
+
+func (m *Client) Me() (tracker.Me, error) {
+	m.record("Me")
+	if m.MeFunc == nil {
+		return tracker.Me{}, m.unexpected("Me")
+	}
+	return m.MeFunc()
+}
+
+func (m *Client) Accounts() ([]tracker.Account, error) {
+	m.record("Accounts")
+	if m.AccountsFunc == nil {
+		return nil, m.unexpected("Accounts")
+	}
+	return m.AccountsFunc()
+}
+
+func (m *Client) Account(accountID int) (tracker.Account, error) {
+	m.record("Account", accountID)
+	if m.AccountFunc == nil {
+		return tracker.Account{}, m.unexpected("Account")
+	}
+	return m.AccountFunc(accountID)
+}
+
+func (m *Client) Workspaces() ([]tracker.Workspace, error) {
+	m.record("Workspaces")
+	if m.WorkspacesFunc == nil {
+		return nil, m.unexpected("Workspaces")
+	}
+	return m.WorkspacesFunc()
+}
+
+func (m *Client) Workspace(workspaceID int) (tracker.Workspace, error) {
+	m.record("Workspace", workspaceID)
+	if m.WorkspaceFunc == nil {
+		return tracker.Workspace{}, m.unexpected("Workspace")
+	}
+	return m.WorkspaceFunc(workspaceID)
+}
+
+func (m *Client) CreateWorkspace(workspace tracker.Workspace) (tracker.Workspace, error) {
+	m.record("CreateWorkspace", workspace)
+	if m.CreateWorkspaceFunc == nil {
+		return tracker.Workspace{}, m.unexpected("CreateWorkspace")
+	}
+	return m.CreateWorkspaceFunc(workspace)
+}
+
+func (m *Client) UpdateWorkspace(workspace tracker.Workspace) (tracker.Workspace, error) {
+	m.record("UpdateWorkspace", workspace)
+	if m.UpdateWorkspaceFunc == nil {
+		return tracker.Workspace{}, m.unexpected("UpdateWorkspace")
+	}
+	return m.UpdateWorkspaceFunc(workspace)
+}
+
+func (m *Client) SetWorkspaceProjects(workspaceID int, projectIDs []int) (tracker.Workspace, error) {
+	m.record("SetWorkspaceProjects", workspaceID, projectIDs)
+	if m.SetWorkspaceProjectsFunc == nil {
+		return tracker.Workspace{}, m.unexpected("SetWorkspaceProjects")
+	}
+	return m.SetWorkspaceProjectsFunc(workspaceID, projectIDs)
+}
+
+func (m *Client) DeleteWorkspace(workspaceID int) error {
+	m.record("DeleteWorkspace", workspaceID)
+	if m.DeleteWorkspaceFunc == nil {
+		return m.unexpected("DeleteWorkspace")
+	}
+	return m.DeleteWorkspaceFunc(workspaceID)
+}
+
+func (m *Client) Story(storyID int) (tracker.Story, error) {
+	m.record("Story", storyID)
+	if m.StoryFunc == nil {
+		return tracker.Story{}, m.unexpected("Story")
+	}
+	return m.StoryFunc(storyID)
+}
+
+func (m *Client) StoryWithQuery(storyID int, query tracker.StoryQuery) (tracker.Story, error) {
+	m.record("StoryWithQuery", storyID, query)
+	if m.StoryWithQueryFunc == nil {
+		return tracker.Story{}, m.unexpected("StoryWithQuery")
+	}
+	return m.StoryWithQueryFunc(storyID, query)
+}
+
+func (m *Client) StoriesByIDs(storyIDs []int) map[int]tracker.StoryResult {
+	m.record("StoriesByIDs", storyIDs)
+	if m.StoriesByIDsFunc == nil {
+		results := make(map[int]tracker.StoryResult, len(storyIDs))
+		for _, storyID := range storyIDs {
+			results[storyID] = tracker.StoryResult{Err: m.unexpected("StoriesByIDs")}
+		}
+		return results
+	}
+	return m.StoriesByIDsFunc(storyIDs)
+}
+
+func (m *Client) InProject(projectID int) tracker.ProjectAPI {
+	m.record("InProject", projectID)
+	if m.InProjectFunc == nil {
+		return &ProjectClient{}
+	}
+	return m.InProjectFunc(projectID)
+}
+
+func (m *Client) InAccount(accountID int) tracker.AccountAPI {
+	m.record("InAccount", accountID)
+	if m.InAccountFunc == nil {
+		return &AccountClient{}
+	}
+	return m.InAccountFunc(accountID)
+}
+
+func (m *Client) InWorkspace(workspaceID int) ([]tracker.ProjectAPI, error) {
+	m.record("InWorkspace", workspaceID)
+	if m.InWorkspaceFunc == nil {
+		return nil, m.unexpected("InWorkspace")
+	}
+	return m.InWorkspaceFunc(workspaceID)
+}
+
+func (m *AccountClient) Memberships() ([]tracker.AccountMembership, error) {
+	m.record("Memberships")
+	if m.MembershipsFunc == nil {
+		return nil, m.unexpected("Memberships")
+	}
+	return m.MembershipsFunc()
+}
+
+func (m *AccountClient) AddMember(membership tracker.NewAccountMembership) (tracker.AccountMembership, error) {
+	m.record("AddMember", membership)
+	if m.AddMemberFunc == nil {
+		return tracker.AccountMembership{}, m.unexpected("AddMember")
+	}
+	return m.AddMemberFunc(membership)
+}
+
+func (m *AccountClient) UpdateMember(personID int, permissions tracker.AccountPermissions) (tracker.AccountMembership, error) {
+	m.record("UpdateMember", personID, permissions)
+	if m.UpdateMemberFunc == nil {
+		return tracker.AccountMembership{}, m.unexpected("UpdateMember")
+	}
+	return m.UpdateMemberFunc(personID, permissions)
+}
+
+func (m *AccountClient) RemoveMember(personID int) error {
+	m.record("RemoveMember", personID)
+	if m.RemoveMemberFunc == nil {
+		return m.unexpected("RemoveMember")
+	}
+	return m.RemoveMemberFunc(personID)
+}
+
+func (m *AccountClient) Projects() ([]tracker.Project, error) {
+	m.record("Projects")
+	if m.ProjectsFunc == nil {
+		return nil, m.unexpected("Projects")
+	}
+	return m.ProjectsFunc()
+}
@@ -0,0 +1,98 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package mock_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/deoxxa/go-tracker"
+	"github.com/deoxxa/go-tracker/mock"
+)
+
+func storyNames(stories tracker.StoryService, ids []int) ([]string, error) {
+	var names []string
+	for _, id := range ids {
+		story, err := stories.Story(id)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, story.Name)
+	}
+	return names, nil
+}
+
+var _ = Describe("ProjectClient", func() {
+	It("calls the function given for a method", func() {
+		project := &mock.ProjectClient{
+			StoryFunc: func(storyID int) (tracker.Story, error) {
+				return tracker.Story{ID: storyID, Name: "Story"}, nil
+			},
+		}
+
+		names, err := storyNames(project, []int{560, 561})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"Story", "Story"}))
+	})
+
+	It("records each call with its arguments", func() {
+		project := &mock.ProjectClient{
+			DeliverStoryWithCommentFunc: func(storyID int, comment string) error {
+				return nil
+			},
+		}
+
+		Expect(project.DeliverStoryWithComment(560, "Ready")).To(Succeed())
+		project.Story(561)
+
+		Expect(project.Calls()).To(Equal([]mock.Call{
+			{Method: "DeliverStoryWithComment", Args: []interface{}{560, "Ready"}},
+			{Method: "Story", Args: []interface{}{561}},
+		}))
+		Expect(project.CallsTo("Story")).To(HaveLen(1))
+	})
+
+	It("fails calls to methods without a function", func() {
+		_, err := (&mock.Client{}).Me()
+		Expect(err).To(Equal(mock.UnexpectedCallError{Method: "Me"}))
+	})
+})
+
+var _ = Describe("Client", func() {
+	It("hands out the project mock given for a project", func() {
+		project := &mock.ProjectClient{
+			StoryFunc: func(storyID int) (tracker.Story, error) {
+				return tracker.Story{ID: storyID, Name: "Story"}, nil
+			},
+		}
+		client := &mock.Client{
+			InProjectFunc: func(projectID int) tracker.ProjectAPI {
+				return project
+			},
+		}
+
+		var api tracker.ClientAPI = client
+		names, err := storyNames(api.InProject(99), []int{560})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"Story"}))
+		Expect(client.Calls()).To(Equal([]mock.Call{
+			{Method: "InProject", Args: []interface{}{99}},
+		}))
+	})
+
+	It("hands out account mocks which fail unexpected calls", func() {
+		_, err := (&mock.Client{}).InAccount(100).Projects()
+		Expect(err).To(Equal(mock.UnexpectedCallError{Method: "Projects"}))
+	})
+})
@@ -0,0 +1,84 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mock provides hand-written stand-ins for the tracker clients,
+// for unit testing code which depends on the interfaces in package tracker.
+//
+//	project := &mock.ProjectClient{
+//		StoryFunc: func(storyID int) (tracker.Story, error) {
+//			return tracker.Story{ID: storyID, Name: "Build the superlaser"}, nil
+//		},
+//	}
+//	story, err := project.Story(560)
+package mock
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Call is a method call made on a mock.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// UnexpectedCallError is returned by a mock method whose function field
+// has not been set.
+type UnexpectedCallError struct {
+	Method string
+}
+
+func (e UnexpectedCallError) Error() string {
+	return fmt.Sprintf("mock: unexpected call to %s", e.Method)
+}
+
+// Recorder keeps the calls made on a mock. It is safe for concurrent use.
+type Recorder struct {
+	mutex sync.Mutex
+	calls []Call
+}
+
+// Calls returns every call made so far, in order.
+func (r *Recorder) Calls() []Call {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the calls made so far to the method.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+func (r *Recorder) unexpected(method string) error {
+	return UnexpectedCallError{Method: method}
+}
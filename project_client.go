@@ -16,21 +16,34 @@ package tracker
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type ProjectClient struct {
-	id   int
-	conn connection
+	id      int
+	version int
+	retry   int
+	conn    connection
+}
+
+// AtVersion returns a client whose writes expect the project to still be
+// at the given version. Tracker rejects them with a ConflictError if it is
+// not.
+func (p ProjectClient) AtVersion(version int) ProjectClient {
+	p.version = version
+	return p
 }
 
 func (p ProjectClient) Project() (*Project, error) {
-	request, err := p.createRequest("GET", "/stories", url.Values{})
+	p.conn = p.conn.named("tracker.Project")
+	request, err := p.createRequest("GET", "", url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +56,26 @@ func (p ProjectClient) Project() (*Project, error) {
 	return &project, err
 }
 
+func (p ProjectClient) Story(storyID int) (Story, error) {
+	p.conn = p.conn.named("tracker.Story")
+	return p.StoryWithQuery(storyID, StoryQuery{})
+}
+
+func (p ProjectClient) StoryWithQuery(storyID int, query StoryQuery) (Story, error) {
+	p.conn = p.conn.named("tracker.StoryWithQuery")
+	url := fmt.Sprintf("/stories/%d", storyID)
+	request, err := p.createRequest("GET", url, query.Query())
+	if err != nil {
+		return Story{}, err
+	}
+
+	var story Story
+	_, err = p.conn.Do(request, &story)
+	return story, err
+}
+
 func (p ProjectClient) Stories(query StoriesQuery) ([]Story, Pagination, error) {
+	p.conn = p.conn.named("tracker.Stories")
 	request, err := p.createRequest("GET", "/stories", query.Query())
 	if err != nil {
 		return nil, Pagination{}, err
@@ -58,7 +90,88 @@ func (p ProjectClient) Stories(query StoriesQuery) ([]Story, Pagination, error)
 	return stories, pagination, err
 }
 
+func (p ProjectClient) Search(query SearchQuery) (SearchResults, error) {
+	p.conn = p.conn.named("tracker.Search")
+	request, err := p.createRequest("GET", "/search", query.Query())
+	if err != nil {
+		return SearchResults{}, err
+	}
+
+	var results SearchResults
+	_, err = p.conn.Do(request, &results)
+	return results, err
+}
+
+func (p ProjectClient) Iterations(query IterationsQuery) ([]Iteration, Pagination, error) {
+	p.conn = p.conn.named("tracker.Iterations")
+	request, err := p.createRequest("GET", "/iterations", query.Query())
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	var iterations []Iteration
+	pagination, err := p.conn.Do(request, &iterations)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return iterations, pagination, err
+}
+
+// maxIDFilterLength keeps the id filter of StoriesByIDs well within the
+// URL length limits of Tracker and the proxies in front of it.
+const maxIDFilterLength = 1500
+
+// StoriesByIDs fetches the stories with the given IDs, splitting them
+// across as many requests as needed. Stories which cannot be found are
+// left out.
+func (p ProjectClient) StoriesByIDs(storyIDs []int) ([]Story, error) {
+	p.conn = p.conn.named("tracker.StoriesByIDs")
+	var stories []Story
+
+	for _, chunk := range chunkIDs(storyIDs, maxIDFilterLength) {
+		query := StoriesQuery{
+			Filter: Filter().ID(chunk...).IncludeDone().Terms(),
+			Limit:  len(chunk),
+		}
+
+		found, _, err := p.Stories(query)
+		if err != nil {
+			return nil, err
+		}
+
+		stories = append(stories, found...)
+	}
+
+	return stories, nil
+}
+
+func chunkIDs(ids []int, maxLength int) [][]int {
+	var chunks [][]int
+	var chunk []int
+	length := 0
+
+	for _, id := range ids {
+		idLength := len(strconv.Itoa(id)) + 1
+		if len(chunk) != 0 && length+idLength > maxLength {
+			chunks = append(chunks, chunk)
+			chunk = nil
+			length = 0
+		}
+
+		chunk = append(chunk, id)
+		length += idLength
+	}
+
+	if len(chunk) != 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
 func (p ProjectClient) Labels(query LabelsQuery) ([]Label, Pagination, error) {
+	p.conn = p.conn.named("tracker.Labels")
 	request, err := p.createRequest("GET", "/labels", query.Query())
 	if err != nil {
 		return nil, Pagination{}, err
@@ -74,6 +187,7 @@ func (p ProjectClient) Labels(query LabelsQuery) ([]Label, Pagination, error) {
 }
 
 func (p ProjectClient) StoryActivity(storyId int, query ActivityQuery) (activities []Activity, err error) {
+	p.conn = p.conn.named("tracker.StoryActivity")
 	url := fmt.Sprintf("/stories/%d/activity", storyId)
 
 	request, err := p.createRequest("GET", url, query.Query())
@@ -85,7 +199,37 @@ func (p ProjectClient) StoryActivity(storyId int, query ActivityQuery) (activiti
 	return activities, err
 }
 
+func (p ProjectClient) StoryTransitions(storyID int) (transitions []StoryTransition, err error) {
+	p.conn = p.conn.named("tracker.StoryTransitions")
+	url := fmt.Sprintf("/stories/%d/transitions", storyID)
+
+	request, err := p.createRequest("GET", url, nil)
+	if err != nil {
+		return transitions, err
+	}
+
+	_, err = p.conn.Do(request, &transitions)
+	return transitions, err
+}
+
+func (p ProjectClient) Transitions(query StoryTransitionsQuery) ([]StoryTransition, Pagination, error) {
+	p.conn = p.conn.named("tracker.Transitions")
+	request, err := p.createRequest("GET", "/story_transitions", query.Query())
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	var transitions []StoryTransition
+	pagination, err := p.conn.Do(request, &transitions)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return transitions, pagination, err
+}
+
 func (p ProjectClient) StoryTasks(storyId int, query TaskQuery) (tasks []Task, err error) {
+	p.conn = p.conn.named("tracker.StoryTasks")
 	url := fmt.Sprintf("/stories/%d/tasks", storyId)
 
 	request, err := p.createRequest("GET", url, query.Query())
@@ -98,6 +242,7 @@ func (p ProjectClient) StoryTasks(storyId int, query TaskQuery) (tasks []Task, e
 }
 
 func (p ProjectClient) StoryComments(storyId int, query CommentsQuery) (comments []Comment, err error) {
+	p.conn = p.conn.named("tracker.StoryComments")
 	url := fmt.Sprintf("/stories/%d/comments", storyId)
 
 	request, err := p.createRequest("GET", url, query.Query())
@@ -110,6 +255,7 @@ func (p ProjectClient) StoryComments(storyId int, query CommentsQuery) (comments
 }
 
 func (p ProjectClient) DeliverStoryWithComment(storyId int, comment string) error {
+	p.conn = p.conn.named("tracker.DeliverStoryWithComment")
 	err := p.DeliverStory(storyId)
 	if err != nil {
 		return err
@@ -126,26 +272,72 @@ func (p ProjectClient) DeliverStoryWithComment(storyId int, comment string) erro
 		Text: comment,
 	})
 
-	p.addJSONBodyReader(request, buffer)
+	p.conn.AddJSONBodyReader(request, buffer)
 
 	_, err = p.conn.Do(request, nil)
 	return err
 }
 
+// DeliverStory checks the story may be delivered, as TransitionStory does,
+// but does not read the story back, so an empty response is accepted.
 func (p ProjectClient) DeliverStory(storyId int) error {
-	url := fmt.Sprintf("/stories/%d", storyId)
-	request, err := p.createRequest("PUT", url, nil)
-	if err != nil {
+	p.conn = p.conn.named("tracker.DeliverStory")
+	if err := p.validateTransition(storyId, StoryStateDelivered); err != nil {
 		return err
 	}
 
-	p.addJSONBody(request, `{"current_state":"delivered"}`)
+	return p.sendStory(storyId, map[string]interface{}{"current_state": StoryStateDelivered}, nil)
+}
 
-	_, err = p.conn.Do(request, nil)
-	return err
+func (p ProjectClient) StartStory(storyID int) (Story, error) {
+	p.conn = p.conn.named("tracker.StartStory")
+	return p.TransitionStory(storyID, StoryStateStarted)
+}
+
+func (p ProjectClient) FinishStory(storyID int) (Story, error) {
+	p.conn = p.conn.named("tracker.FinishStory")
+	return p.TransitionStory(storyID, StoryStateFinished)
+}
+
+func (p ProjectClient) AcceptStory(storyID int) (Story, error) {
+	p.conn = p.conn.named("tracker.AcceptStory")
+	return p.TransitionStory(storyID, StoryStateAccepted)
+}
+
+func (p ProjectClient) RejectStory(storyID int, reason string) (Story, error) {
+	p.conn = p.conn.named("tracker.RejectStory")
+	story, err := p.TransitionStory(storyID, StoryStateRejected)
+	if err != nil {
+		return story, err
+	}
+
+	if reason != "" {
+		_, err = p.CreateComment(storyID, Comment{Text: reason})
+	}
+
+	return story, err
+}
+
+func (p ProjectClient) TransitionStory(storyID int, state StoryState) (Story, error) {
+	p.conn = p.conn.named("tracker.TransitionStory")
+	if err := p.validateTransition(storyID, state); err != nil {
+		return Story{}, err
+	}
+
+	return p.putStory(storyID, map[string]interface{}{"current_state": state})
+}
+
+func (p ProjectClient) validateTransition(storyID int, state StoryState) error {
+	story, err := p.Story(storyID)
+	if err != nil {
+		return err
+	}
+
+	return story.ValidateTransition(state)
 }
 
 func (p ProjectClient) CreateStory(story NewStory) (Story, error) {
+	p.conn = p.conn.named("tracker.CreateStory")
 	request, err := p.createRequest("POST", "/stories", nil)
 	if err != nil {
 		return Story{}, err
@@ -154,7 +346,7 @@ func (p ProjectClient) CreateStory(story NewStory) (Story, error) {
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(story)
 
-	p.addJSONBodyReader(request, buffer)
+	p.conn.AddJSONBodyReader(request, buffer)
 
 	var createdStory Story
 	_, err = p.conn.Do(request, &createdStory)
@@ -162,6 +354,7 @@ func (p ProjectClient) CreateStory(story NewStory) (Story, error) {
 }
 
 func (p ProjectClient) UpdateStoryLabels(storyID int, labels []string) (Story, error) {
+	p.conn = p.conn.named("tracker.UpdateStoryLabels")
 	url := fmt.Sprintf("/stories/%d", storyID)
 	request, err := p.createRequest("PUT", url, nil)
 	if err != nil {
@@ -171,7 +364,7 @@ func (p ProjectClient) UpdateStoryLabels(storyID int, labels []string) (Story, e
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(map[string]interface{}{"labels": labels})
 
-	p.addJSONBodyReader(request, buffer)
+	p.conn.AddJSONBodyReader(request, buffer)
 
 	var updatedStory Story
 	_, err = p.conn.Do(request, &updatedStory)
@@ -179,6 +372,7 @@ func (p ProjectClient) UpdateStoryLabels(storyID int, labels []string) (Story, e
 }
 
 func (p ProjectClient) UpdateStory(story Story) (Story, error) {
+	p.conn = p.conn.named("tracker.UpdateStory")
 	url := fmt.Sprintf("/stories/%d", story.ID)
 	request, err := p.createRequest("PUT", url, nil)
 	if err != nil {
@@ -188,14 +382,228 @@ func (p ProjectClient) UpdateStory(story Story) (Story, error) {
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(story)
 
-	p.addJSONBodyReader(request, buffer)
+	p.conn.AddJSONBodyReader(request, buffer)
 
 	var updatedStory Story
 	_, err = p.conn.Do(request, &updatedStory)
 	return updatedStory, err
 }
 
+func (p ProjectClient) MoveStory(storyID int, position StoryPosition) (Story, error) {
+	p.conn = p.conn.named("tracker.MoveStory")
+	if (position.BeforeID == 0) == (position.AfterID == 0) {
+		return Story{}, errors.New("exactly one of before or after must be given to move a story")
+	}
+
+	return p.putStory(storyID, position)
+}
+
+func (p ProjectClient) MoveStories(storyIDs []int, position StoryPosition) ([]Story, error) {
+	p.conn = p.conn.named("tracker.MoveStories")
+	movedStories := make([]Story, 0, len(storyIDs))
+
+	for _, storyID := range storyIDs {
+		movedStory, err := p.MoveStory(storyID, position)
+		if err != nil {
+			return movedStories, err
+		}
+
+		movedStories = append(movedStories, movedStory)
+		position = StoryPosition{AfterID: storyID}
+	}
+
+	return movedStories, nil
+}
+
+func (p ProjectClient) MoveStoryToProject(storyID int, projectID int) (Story, error) {
+	p.conn = p.conn.named("tracker.MoveStoryToProject")
+	return p.putStory(storyID, map[string]interface{}{"project_id": projectID})
+}
+
+func (p ProjectClient) putStory(storyID int, body interface{}) (Story, error) {
+	var updatedStory Story
+	err := p.sendStory(storyID, body, &updatedStory)
+	return updatedStory, err
+}
+
+// sendStory PUTs body to the story, decoding the response into updatedStory
+// unless it is nil.
+func (p ProjectClient) sendStory(storyID int, body interface{}, updatedStory *Story) error {
+	url := fmt.Sprintf("/stories/%d", storyID)
+	request, err := p.createRequest("PUT", url, nil)
+	if err != nil {
+		return err
+	}
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(body)
+
+	p.conn.AddJSONBodyReader(request, buffer)
+
+	if updatedStory == nil {
+		_, err = p.conn.Do(request, nil)
+		return err
+	}
+
+	_, err = p.conn.Do(request, updatedStory)
+	return err
+}
+
+// StoryWithVersion fetches a story along with the version of the project
+// it was read at, for use with AtVersion.
+func (p ProjectClient) StoryWithVersion(storyID int) (Story, int, error) {
+	p.conn = p.conn.named("tracker.StoryWithVersion")
+	url := fmt.Sprintf("/stories/%d", storyID)
+	request, err := p.createRequest("GET", url, nil)
+	if err != nil {
+		return Story{}, 0, err
+	}
+
+	var story Story
+	_, version, err := p.conn.DoWithVersion(request, &story)
+	if err != nil {
+		return Story{}, 0, err
+	}
+
+	return story, version, nil
+}
+
+// maxModifyAttempts is how many times ModifyStory tries to apply a change
+// which keeps conflicting with other changes to the project.
+const maxModifyAttempts = 5
+
+// ModifyStory fetches a story, applies modify to it and saves the fields it
+// changed, starting again from a fresh copy of the story if the project
+// changed in the meantime. Only name, description, type, state, estimate,
+// labels, owners and requester can be changed; if modify changes any other
+// field nothing is saved and an error is returned. If modify changes
+// nothing the story is returned without being saved. An error returned by
+// modify is returned as is.
+func (p ProjectClient) ModifyStory(storyID int, modify func(*Story) error) (Story, error) {
+	p.conn = p.conn.named("tracker.ModifyStory")
+	var err error
+
+	for attempt := 0; attempt < maxModifyAttempts; attempt++ {
+		story, version, fetchErr := p.StoryWithVersion(storyID)
+		if fetchErr != nil {
+			return Story{}, fetchErr
+		}
+
+		modified := copyStory(story)
+		if modifyErr := modify(&modified); modifyErr != nil {
+			return Story{}, modifyErr
+		}
+
+		changes, changesErr := storyChanges(story, modified)
+		if changesErr != nil {
+			return Story{}, changesErr
+		}
+		if len(changes) == 0 {
+			return story, nil
+		}
+
+		client := p.AtVersion(version)
+		client.retry = attempt
+
+		var updatedStory Story
+		updatedStory, err = client.putStory(storyID, changes)
+		if _, conflict := err.(ConflictError); !conflict {
+			return updatedStory, err
+		}
+	}
+
+	return Story{}, err
+}
+
+// copyStory copies a story and the values it refers to, so that changes
+// made to the copy in place leave the original alone.
+func copyStory(story Story) Story {
+	if story.Estimate != nil {
+		story.Estimate = Estimate(*story.Estimate)
+	}
+	if story.RequestedBy != nil {
+		requestedBy := *story.RequestedBy
+		story.RequestedBy = &requestedBy
+	}
+
+	story.Labels = append([]Label(nil), story.Labels...)
+	story.OwnerIDs = append([]int(nil), story.OwnerIDs...)
+	story.Owners = append([]Person(nil), story.Owners...)
+	story.Tasks = append([]Task(nil), story.Tasks...)
+	story.Comments = append([]Comment(nil), story.Comments...)
+	story.Blockers = append([]Blocker(nil), story.Blockers...)
+
+	story.CreatedAt = copyTime(story.CreatedAt)
+	story.UpdatedAt = copyTime(story.UpdatedAt)
+	story.AcceptedAt = copyTime(story.AcceptedAt)
+
+	return story
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
+
+// writableStoryFields are the fields, by JSON name, which ModifyStory
+// saves.
+var writableStoryFields = map[string]bool{
+	"name":            true,
+	"description":     true,
+	"story_type":      true,
+	"current_state":   true,
+	"estimate":        true,
+	"labels":          true,
+	"owner_ids":       true,
+	"requested_by_id": true,
+}
+
+// storyChanges returns the writable fields of after which differ from
+// before, by JSON name. Emptied lists are sent as empty rather than null.
+// A change to any other field is an error rather than being dropped.
+func storyChanges(before, after Story) (map[string]interface{}, error) {
+	beforeValue := reflect.ValueOf(before)
+	afterValue := reflect.ValueOf(after)
+
+	changes := map[string]interface{}{}
+	var readOnly []string
+
+	for i := 0; i < afterValue.NumField(); i++ {
+		name := strings.Split(afterValue.Type().Field(i).Tag.Get("json"), ",")[0]
+		original := beforeValue.Field(i)
+		field := afterValue.Field(i)
+
+		if field.Kind() == reflect.Slice && original.Len() == 0 && field.Len() == 0 {
+			continue
+		}
+		if reflect.DeepEqual(original.Interface(), field.Interface()) {
+			continue
+		}
+
+		if !writableStoryFields[name] {
+			readOnly = append(readOnly, name)
+			continue
+		}
+
+		value := field.Interface()
+		if field.Kind() == reflect.Slice && field.IsNil() {
+			value = reflect.MakeSlice(field.Type(), 0, 0).Interface()
+		}
+		changes[name] = value
+	}
+
+	if len(readOnly) != 0 {
+		return nil, fmt.Errorf("ModifyStory cannot save changes to %s", strings.Join(readOnly, ", "))
+	}
+
+	return changes, nil
+}
+
 func (p ProjectClient) DeleteStory(storyId int) error {
+	p.conn = p.conn.named("tracker.DeleteStory")
 	url := fmt.Sprintf("/stories/%d", storyId)
 	request, err := p.createRequest("DELETE", url, nil)
 	if err != nil {
@@ -207,6 +615,7 @@ func (p ProjectClient) DeleteStory(storyId int) error {
 }
 
 func (p ProjectClient) CreateTask(storyID int, task Task) (Task, error) {
+	p.conn = p.conn.named("tracker.CreateTask")
 	url := fmt.Sprintf("/stories/%d/tasks", storyID)
 	request, err := p.createRequest("POST", url, nil)
 	if err != nil {
@@ -216,7 +625,7 @@ func (p ProjectClient) CreateTask(storyID int, task Task) (Task, error) {
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(task)
 
-	p.addJSONBodyReader(request, buffer)
+	p.conn.AddJSONBodyReader(request, buffer)
 
 	var createdTask Task
 	_, err = p.conn.Do(request, &createdTask)
@@ -224,6 +633,7 @@ func (p ProjectClient) CreateTask(storyID int, task Task) (Task, error) {
 }
 
 func (p ProjectClient) CreateComment(storyID int, comment Comment) (Comment, error) {
+	p.conn = p.conn.named("tracker.CreateComment")
 	url := fmt.Sprintf("/stories/%d/comments", storyID)
 	request, err := p.createRequest("POST", url, nil)
 	if err != nil {
@@ -233,7 +643,7 @@ func (p ProjectClient) CreateComment(storyID int, comment Comment) (Comment, err
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(comment)
 
-	p.addJSONBodyReader(request, buffer)
+	p.conn.AddJSONBodyReader(request, buffer)
 
 	var createdComment Comment
 	_, err = p.conn.Do(request, &createdComment)
@@ -241,6 +651,7 @@ func (p ProjectClient) CreateComment(storyID int, comment Comment) (Comment, err
 }
 
 func (p ProjectClient) CreateBlocker(storyID int, blocker Blocker) (Blocker, error) {
+	p.conn = p.conn.named("tracker.CreateBlocker")
 	url := fmt.Sprintf("/stories/%d/blockers", storyID)
 	request, err := p.createRequest("POST", url, nil)
 	if err != nil {
@@ -250,7 +661,7 @@ func (p ProjectClient) CreateBlocker(storyID int, blocker Blocker) (Blocker, err
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(blocker)
 
-	p.addJSONBodyReader(request, buffer)
+	p.conn.AddJSONBodyReader(request, buffer)
 
 	var createdBlocker Blocker
 	_, err = p.conn.Do(request, &createdBlocker)
@@ -258,6 +669,7 @@ func (p ProjectClient) CreateBlocker(storyID int, blocker Blocker) (Blocker, err
 }
 
 func (p ProjectClient) ProjectMemberships() ([]ProjectMembership, error) {
+	p.conn = p.conn.named("tracker.ProjectMemberships")
 	request, err := p.createRequest("GET", "/memberships", nil)
 	if err != nil {
 		return []ProjectMembership{}, err
@@ -272,16 +684,76 @@ func (p ProjectClient) ProjectMemberships() ([]ProjectMembership, error) {
 	return memberships, nil
 }
 
-func (p ProjectClient) createRequest(method string, path string, params url.Values) (*http.Request, error) {
-	projectPath := fmt.Sprintf("/projects/%d%s", p.id, path)
-	return p.conn.CreateRequest(method, projectPath, params)
+func (p ProjectClient) AddMember(membership NewProjectMembership) (ProjectMembership, error) {
+	p.conn = p.conn.named("tracker.AddMember")
+	request, err := p.createRequest("POST", "/memberships", nil)
+	if err != nil {
+		return ProjectMembership{}, err
+	}
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(membership)
+
+	p.conn.AddJSONBodyReader(request, buffer)
+
+	var createdMembership ProjectMembership
+	_, err = p.conn.Do(request, &createdMembership)
+	return createdMembership, err
+}
+
+func (p ProjectClient) UpdateMemberRole(membershipID int, role ProjectRole) (ProjectMembership, error) {
+	p.conn = p.conn.named("tracker.UpdateMemberRole")
+	url := fmt.Sprintf("/memberships/%d", membershipID)
+	request, err := p.createRequest("PUT", url, nil)
+	if err != nil {
+		return ProjectMembership{}, err
+	}
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(map[string]interface{}{"role": role})
+
+	p.conn.AddJSONBodyReader(request, buffer)
+
+	var updatedMembership ProjectMembership
+	_, err = p.conn.Do(request, &updatedMembership)
+	return updatedMembership, err
 }
 
-func (p ProjectClient) addJSONBodyReader(request *http.Request, body io.Reader) {
-	request.Header.Add("Content-Type", "application/json")
-	request.Body = ioutil.NopCloser(body)
+func (p ProjectClient) RemoveMember(membershipID int) error {
+	p.conn = p.conn.named("tracker.RemoveMember")
+	url := fmt.Sprintf("/memberships/%d", membershipID)
+	request, err := p.createRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.conn.Do(request, nil)
+	return err
 }
 
-func (p ProjectClient) addJSONBody(request *http.Request, body string) {
-	p.addJSONBodyReader(request, strings.NewReader(body))
+func (p ProjectClient) requestURI(path string) (string, error) {
+	request, err := p.createRequest("GET", path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return request.URL.RequestURI(), nil
+}
+
+func (p ProjectClient) createRequest(method string, path string, params url.Values) (*http.Request, error) {
+	projectPath := fmt.Sprintf("/projects/%d%s", p.id, path)
+	request, err := p.conn.CreateRequest(method, projectPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.version != 0 && method != "GET" {
+		request.Header.Set(projectVersionHeader, strconv.Itoa(p.version))
+	}
+
+	if p.retry > 0 {
+		request = request.WithContext(withRetry(request.Context(), p.retry))
+	}
+
+	return request, nil
 }
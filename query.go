@@ -24,6 +24,44 @@ type Query interface {
 	Query() url.Values
 }
 
+// FieldsDefault stands for the fields Tracker returns when none are asked
+// for, so that extra fields can be added to them.
+const FieldsDefault = ":default"
+
+// Fields selects the fields Tracker returns for each resource, including
+// nested resources which are otherwise left out. Leaving it empty gets the
+// default fields.
+//
+//	Fields{FieldsDefault, "owners", "tasks", NestedFields("comments", "text", "person")}
+type Fields []string
+
+// NestedFields selects some fields of a nested resource.
+func NestedFields(name string, fields ...string) string {
+	return name + "(" + strings.Join(fields, ",") + ")"
+}
+
+func (fields Fields) String() string {
+	return strings.Join(fields, ",")
+}
+
+func (fields Fields) addTo(params url.Values) {
+	if len(fields) != 0 {
+		params.Set("fields", fields.String())
+	}
+}
+
+type StoryQuery struct {
+	Fields Fields
+}
+
+func (query StoryQuery) Query() url.Values {
+	params := url.Values{}
+
+	query.Fields.addTo(params)
+
+	return params
+}
+
 type StoriesQuery struct {
 	State  StoryState
 	Label  string
@@ -36,6 +74,8 @@ type StoriesQuery struct {
 	UpdatedBefore  time.Time
 	UpdatedAfter   time.Time
 
+	Fields Fields
+
 	Limit  int
 	Offset int
 }
@@ -43,6 +83,8 @@ type StoriesQuery struct {
 func (query StoriesQuery) Query() url.Values {
 	params := url.Values{}
 
+	query.Fields.addTo(params)
+
 	if query.State != "" {
 		params.Set("with_state", string(query.State))
 	}
@@ -85,17 +127,38 @@ func (query StoriesQuery) Query() url.Values {
 	return params
 }
 
+type SearchQuery struct {
+	Filter []string
+	Fields Fields
+}
+
+func (query SearchQuery) Query() url.Values {
+	params := url.Values{}
+
+	query.Fields.addTo(params)
+
+	if len(query.Filter) != 0 {
+		params.Set("query", strings.Join(query.Filter, " "))
+	}
+
+	return params
+}
+
 type ActivityQuery struct {
 	Limit          int
 	Offset         int
 	OccurredBefore int64
 	OccurredAfter  int64
 	SinceVersion   int
+
+	Fields Fields
 }
 
 func (query ActivityQuery) Query() url.Values {
 	params := url.Values{}
 
+	query.Fields.addTo(params)
+
 	if query.Limit != 0 {
 		params.Set("limit", fmt.Sprintf("%d", query.Limit))
 	}
@@ -119,19 +182,90 @@ func (query ActivityQuery) Query() url.Values {
 	return params
 }
 
-type TaskQuery struct{}
+type StoryTransitionsQuery struct {
+	OccurredBefore time.Time
+	OccurredAfter  time.Time
+
+	Limit  int
+	Offset int
+}
+
+func (query StoryTransitionsQuery) Query() url.Values {
+	params := url.Values{}
+
+	if !query.OccurredBefore.IsZero() {
+		params.Set("occurred_before", query.OccurredBefore.Format(time.RFC3339))
+	}
+	if !query.OccurredAfter.IsZero() {
+		params.Set("occurred_after", query.OccurredAfter.Format(time.RFC3339))
+	}
+
+	if query.Limit != 0 {
+		params.Set("limit", fmt.Sprintf("%d", query.Limit))
+	}
+
+	if query.Offset != 0 {
+		params.Set("offset", fmt.Sprintf("%d", query.Offset))
+	}
+
+	return params
+}
+
+type IterationsQuery struct {
+	Scope  IterationScope
+	Fields Fields
+
+	Limit  int
+	Offset int
+}
+
+func (query IterationsQuery) Query() url.Values {
+	params := url.Values{}
+
+	query.Fields.addTo(params)
+
+	if query.Scope != "" {
+		params.Set("scope", string(query.Scope))
+	}
+
+	if query.Limit != 0 {
+		params.Set("limit", fmt.Sprintf("%d", query.Limit))
+	}
+
+	if query.Offset != 0 {
+		params.Set("offset", fmt.Sprintf("%d", query.Offset))
+	}
+
+	return params
+}
+
+type TaskQuery struct {
+	Fields Fields
+}
 
 func (query TaskQuery) Query() url.Values {
-	return url.Values{}
+	params := url.Values{}
+
+	query.Fields.addTo(params)
+
+	return params
 }
 
-type CommentsQuery struct{}
+type CommentsQuery struct {
+	Fields Fields
+}
 
 func (query CommentsQuery) Query() url.Values {
-	return url.Values{}
+	params := url.Values{}
+
+	query.Fields.addTo(params)
+
+	return params
 }
 
 type LabelsQuery struct {
+	Fields Fields
+
 	Limit  int
 	Offset int
 }
@@ -139,6 +273,8 @@ type LabelsQuery struct {
 func (query LabelsQuery) Query() url.Values {
 	params := url.Values{}
 
+	query.Fields.addTo(params)
+
 	if query.Limit != 0 {
 		params.Set("limit", fmt.Sprintf("%d", query.Limit))
 	}
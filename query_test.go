@@ -65,6 +65,13 @@ var _ = Describe("Queries", func() {
 			})
 		})
 
+		It("can select the fields returned", func() {
+			query := tracker.StoriesQuery{
+				Fields: tracker.Fields{"name", "owners"},
+			}
+			Expect(queryString(query)).To(Equal("fields=name%2Cowners"))
+		})
+
 		It("can limit the numer of results", func() {
 			query := tracker.StoriesQuery{
 				Limit: 33,
@@ -72,4 +79,31 @@ var _ = Describe("Queries", func() {
 			Expect(queryString(query)).To(Equal("limit=33"))
 		})
 	})
+
+	Describe("Fields", func() {
+		It("joins fields with commas", func() {
+			fields := tracker.Fields{tracker.FieldsDefault, "owners", tracker.NestedFields("comments", "text", "person")}
+			Expect(fields.String()).To(Equal(":default,owners,comments(text,person)"))
+		})
+
+		It("can be given to other queries", func() {
+			Expect(queryString(tracker.ActivityQuery{Fields: tracker.Fields{"message"}})).To(Equal("fields=message"))
+			Expect(queryString(tracker.TaskQuery{Fields: tracker.Fields{"description"}})).To(Equal("fields=description"))
+			Expect(queryString(tracker.CommentsQuery{Fields: tracker.Fields{"person"}})).To(Equal("fields=person"))
+			Expect(queryString(tracker.StoryQuery{})).To(Equal(""))
+		})
+	})
+
+	Describe("SearchQuery", func() {
+		It("is empty by default", func() {
+			Expect(queryString(tracker.SearchQuery{})).To(Equal(""))
+		})
+
+		It("joins the filter into the search query", func() {
+			query := tracker.SearchQuery{
+				Filter: []string{"owner:dv", "state:started"},
+			}
+			Expect(queryString(query)).To(Equal("query=owner%3Adv+state%3Astarted"))
+		})
+	})
 })
@@ -0,0 +1,368 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recorder records HTTP interactions with Tracker to JSON cassette
+// files and replays them, so that integration tests can be refreshed
+// against the real API once and run offline afterwards.
+//
+//	r, err := recorder.New("fixtures/cassettes/deliver.json", recorder.ModeAuto)
+//	if err != nil {
+//		return err
+//	}
+//	defer r.Stop()
+//
+//	client := tracker.NewClient(os.Getenv("TRACKER_TOKEN"))
+//	client.SetHTTPClient(r.Client())
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ScrubbedToken replaces the API token in recorded requests, and in the
+// api_token fields of recorded JSON responses such as /me.
+const ScrubbedToken = "[SCRUBBED]"
+
+type Mode int
+
+const (
+	// ModeReplay serves responses from an existing cassette and never
+	// touches the network.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests to Tracker and records them, replacing
+	// the cassette when the recorder is stopped.
+	ModeRecord
+
+	// ModeAuto replays the cassette if it exists and records it if not.
+	ModeAuto
+)
+
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is kept as JSON in the cassette when it is JSON, so that cassettes
+// read like the files in fixtures/, and as a string otherwise.
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if len(b) == 0 {
+		return []byte("null"), nil
+	}
+	if json.Valid(b) {
+		return json.Marshal(map[string]json.RawMessage{"json": json.RawMessage(b)})
+	}
+	return json.Marshal(map[string]string{"text": string(b)})
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var body struct {
+		JSON json.RawMessage `json:"json"`
+		Text string          `json:"text"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	if len(body.JSON) > 0 {
+		*b = Body(body.JSON)
+	} else {
+		*b = Body(body.Text)
+	}
+	return nil
+}
+
+// NoInteractionError is returned when replaying a request that the
+// cassette has no unused interaction for.
+type NoInteractionError struct {
+	Method string
+	URL    string
+}
+
+func (e NoInteractionError) Error() string {
+	return fmt.Sprintf("recorder: no recorded interaction for %s %s", e.Method, e.URL)
+}
+
+// Recorder is an http.RoundTripper which records or replays a cassette.
+type Recorder struct {
+	// Transport sends requests when recording. It defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	path      string
+	recording bool
+
+	mutex    sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New makes a recorder for the cassette at path.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		recording: mode == ModeRecord,
+	}
+
+	if mode == ModeAuto {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			r.recording = true
+		}
+	}
+
+	if !r.recording {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %s", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %s", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Recording reports whether the recorder is recording rather than
+// replaying.
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// Client returns an HTTP client which sends its requests through the
+// recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, err := readBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.recording {
+		return r.record(request, body)
+	}
+
+	return r.replay(request, body)
+}
+
+// Stop writes the cassette if the recorder was recording.
+func (r *Recorder) Stop() error {
+	if !r.recording {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "   ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+func (r *Recorder) record(request *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+	header := cloneHeader(request.Header)
+	if header.Get("X-TrackerToken") != "" {
+		header.Set("X-TrackerToken", ScrubbedToken)
+	}
+
+	r.mutex.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: request.Method,
+			URL:    request.URL.String(),
+			Header: header,
+			Body:   body,
+		},
+		Response: Response{
+			StatusCode: response.StatusCode,
+			Header:     cloneHeader(response.Header),
+			Body:       scrubBody(responseBody),
+		},
+	})
+	r.mutex.Unlock()
+
+	return response, nil
+}
+
+// replay answers with the first unused interaction whose method, path,
+// query and body match the request. The host is ignored so that cassettes
+// recorded against Tracker can be replayed with any base URL.
+func (r *Recorder) replay(request *http.Request, body []byte) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction.Request, request, body) {
+			continue
+		}
+		r.used[i] = true
+
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        cloneHeader(recorded.Header),
+			Body:          ioutil.NopCloser(bytes.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       request,
+		}, nil
+	}
+
+	return nil, NoInteractionError{Method: request.Method, URL: request.URL.String()}
+}
+
+func matches(recorded Request, request *http.Request, body []byte) bool {
+	if recorded.Method != request.Method {
+		return false
+	}
+
+	recordedURL, err := request.URL.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	if recordedURL.Path != request.URL.Path || recordedURL.Query().Encode() != request.URL.Query().Encode() {
+		return false
+	}
+
+	return bytes.Equal(compact(recorded.Body), compact(body))
+}
+
+func compact(body []byte) []byte {
+	var buffer bytes.Buffer
+	if json.Compact(&buffer, body) != nil {
+		return body
+	}
+	return buffer.Bytes()
+}
+
+func readBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %s", err)
+	}
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// scrubBody replaces the value of every api_token field in a JSON body.
+// Bodies without one are kept byte for byte.
+func scrubBody(body []byte) []byte {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&value) != nil || !scrubTokens(value) {
+		return body
+	}
+
+	scrubbed, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return scrubbed
+}
+
+func scrubTokens(value interface{}) bool {
+	scrubbed := false
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if key == "api_token" {
+				value[key] = ScrubbedToken
+				scrubbed = true
+			} else if scrubTokens(field) {
+				scrubbed = true
+			}
+		}
+	case []interface{}:
+		for _, element := range value {
+			if scrubTokens(element) {
+				scrubbed = true
+			}
+		}
+	}
+
+	return scrubbed
+}
+
+func cloneHeader(header http.Header) http.Header {
+	clone := http.Header{}
+	for key, values := range header {
+		clone[key] = append([]string(nil), values...)
+	}
+	return clone
+}
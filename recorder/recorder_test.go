@@ -0,0 +1,124 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package recorder_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/deoxxa/go-tracker"
+	"github.com/deoxxa/go-tracker/recorder"
+	"github.com/deoxxa/go-tracker/trackertest"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		dir      string
+		cassette string
+		server   *trackertest.Server
+		project  tracker.Project
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "recorder")
+		Expect(err).NotTo(HaveOccurred())
+		cassette = filepath.Join(dir, "cassettes", "stories.json")
+
+		server = trackertest.NewServer()
+		project = server.AddProject(tracker.Project{Name: "Death Star"})
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	record := func() tracker.Story {
+		r, err := recorder.New(cassette, recorder.ModeAuto)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Recording()).To(BeTrue())
+
+		client := server.Client()
+		client.SetHTTPClient(r.Client())
+
+		story, err := client.InProject(project.ID).CreateStory(tracker.NewStory{Name: "Build the superlaser"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.InProject(project.ID).Story(story.ID)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Stop()).To(Succeed())
+		return story
+	}
+
+	It("records interactions with the token scrubbed", func() {
+		record()
+
+		data, err := ioutil.ReadFile(cassette)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring(server.Token))
+		Expect(string(data)).To(ContainSubstring(recorder.ScrubbedToken))
+		Expect(string(data)).To(ContainSubstring(`"name": "Build the superlaser"`))
+	})
+
+	It("scrubs the token from recorded responses", func() {
+		r, err := recorder.New(cassette, recorder.ModeAuto)
+		Expect(err).NotTo(HaveOccurred())
+
+		client := server.Client()
+		client.SetHTTPClient(r.Client())
+
+		me, err := client.Me()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(me.Username).To(Equal("trackertest"))
+		Expect(r.Stop()).To(Succeed())
+
+		data, err := ioutil.ReadFile(cassette)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring(server.Token))
+		Expect(string(data)).To(ContainSubstring(`"api_token": "` + recorder.ScrubbedToken + `"`))
+	})
+
+	It("replays the recorded interactions without the network", func() {
+		recorded := record()
+		server.Close()
+
+		r, err := recorder.New(cassette, recorder.ModeAuto)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Recording()).To(BeFalse())
+
+		client := tracker.NewClient("any token")
+		client.SetHTTPClient(r.Client())
+
+		story, err := client.InProject(project.ID).CreateStory(tracker.NewStory{Name: "Build the superlaser"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(story).To(Equal(recorded))
+
+		story, err = client.InProject(project.ID).Story(recorded.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(story.Name).To(Equal("Build the superlaser"))
+
+		_, err = client.InProject(project.ID).Story(recorded.ID)
+		Expect(err).To(MatchError(ContainSubstring("no recorded interaction for GET")))
+	})
+
+	It("fails to replay a missing cassette", func() {
+		_, err := recorder.New(cassette, recorder.ModeReplay)
+		Expect(err).To(MatchError(ContainSubstring("failed to read cassette")))
+	})
+})
@@ -13,7 +13,12 @@
 // limitations under the License.
 package tracker
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
 
 type Me Person
 
@@ -71,9 +76,18 @@ type Story struct {
 	Description string     `json:"description,omitempty"`
 	Type        StoryType  `json:"story_type,omitempty"`
 	State       StoryState `json:"current_state,omitempty"`
-	Estimate    int        `json:"estimate,omitempty"`
+	Estimate    *int       `json:"estimate,omitempty"`
+
+	Labels   []Label `json:"labels,omitempty"`
+	OwnerIDs []int   `json:"owner_ids,omitempty"`
+
+	RequestedByID int `json:"requested_by_id,omitempty"`
 
-	Labels []Label `json:"labels,omitempty"`
+	// These are only filled in when asked for with Fields.
+	RequestedBy *Person   `json:"requested_by,omitempty"`
+	Owners      []Person  `json:"owners,omitempty"`
+	Tasks       []Task    `json:"tasks,omitempty"`
+	Comments    []Comment `json:"comments,omitempty"`
 
 	CreatedAt  *time.Time `json:"created_at,omitempty"`
 	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
@@ -81,6 +95,53 @@ type Story struct {
 	Blockers   []Blocker  `json:"blockers,omitempty"`
 }
 
+// Estimate returns a pointer to points, for setting Story.Estimate.
+func Estimate(points int) *int {
+	return &points
+}
+
+// Points is the story's estimate, or zero if it has none.
+func (s Story) Points() int {
+	if s.Estimate == nil {
+		return 0
+	}
+	return *s.Estimate
+}
+
+type Epic struct {
+	Kind      string `json:"kind,omitempty"`
+	ID        int    `json:"id,omitempty"`
+	ProjectID int    `json:"project_id,omitempty"`
+
+	URL string `json:"url,omitempty"`
+
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Label       Label  `json:"label"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type SearchResults struct {
+	Query   string               `json:"query"`
+	Stories StoriesSearchResults `json:"stories"`
+	Epics   EpicsSearchResults   `json:"epics"`
+}
+
+type StoriesSearchResults struct {
+	Stories              []Story `json:"stories"`
+	TotalPoints          int     `json:"total_points"`
+	TotalPointsCompleted int     `json:"total_points_completed"`
+	TotalHits            int     `json:"total_hits"`
+	TotalHitsWithDone    int     `json:"total_hits_with_done"`
+}
+
+type EpicsSearchResults struct {
+	Epics     []Epic `json:"epics"`
+	TotalHits int    `json:"total_hits"`
+}
+
 type NewStory struct {
 	Name        string     `json:"name,omitempty"`
 	Description string     `json:"description,omitempty"`
@@ -90,6 +151,12 @@ type NewStory struct {
 	Tasks       []Task     `json:"tasks,omitempty"`
 	StoryIDs    []int      `json:"story_ids,omitempty"`
 	OwnerIDs    []int      `json:"owner_ids,omitempty"`
+	Blockers    []Blocker  `json:"blockers,omitempty"`
+}
+
+type StoryPosition struct {
+	BeforeID int `json:"before_id,omitempty"`
+	AfterID  int `json:"after_id,omitempty"`
 }
 
 type Task struct {
@@ -105,8 +172,16 @@ type Task struct {
 }
 
 type Comment struct {
-	ID   int    `json:"id,omitempty"`
-	Text string `json:"text,omitempty"`
+	ID       int    `json:"id,omitempty"`
+	StoryID  int    `json:"story_id,omitempty"`
+	PersonID int    `json:"person_id,omitempty"`
+	Text     string `json:"text,omitempty"`
+
+	// Person is only filled in when asked for with Fields.
+	Person *Person `json:"person,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
 type Blocker struct {
@@ -127,24 +202,141 @@ type Label struct {
 type StoryType string
 
 const (
-	StoryTypeFeature = "feature"
-	StoryTypeBug     = "bug"
-	StoryTypeChore   = "chore"
-	StoryTypeRelease = "release"
+	StoryTypeFeature StoryType = "feature"
+	StoryTypeBug     StoryType = "bug"
+	StoryTypeChore   StoryType = "chore"
+	StoryTypeRelease StoryType = "release"
 )
 
+func AllStoryTypes() []StoryType {
+	return []StoryType{
+		StoryTypeFeature,
+		StoryTypeBug,
+		StoryTypeChore,
+		StoryTypeRelease,
+	}
+}
+
+func ParseStoryType(value string) (StoryType, error) {
+	storyType := StoryType(value)
+	if !storyType.Valid() {
+		return "", fmt.Errorf("unknown story type: %q", value)
+	}
+
+	return storyType, nil
+}
+
+func (t StoryType) Valid() bool {
+	for _, storyType := range AllStoryTypes() {
+		if t == storyType {
+			return true
+		}
+	}
+	return false
+}
+
 type StoryState string
 
 const (
-	StoryStateUnscheduled = "unscheduled"
-	StoryStatePlanned     = "planned"
-	StoryStateStarted     = "started"
-	StoryStateFinished    = "finished"
-	StoryStateDelivered   = "delivered"
-	StoryStateAccepted    = "accepted"
-	StoryStateRejected    = "rejected"
+	StoryStateUnscheduled StoryState = "unscheduled"
+	StoryStateUnstarted   StoryState = "unstarted"
+	StoryStatePlanned     StoryState = "planned"
+	StoryStateStarted     StoryState = "started"
+	StoryStateFinished    StoryState = "finished"
+	StoryStateDelivered   StoryState = "delivered"
+	StoryStateAccepted    StoryState = "accepted"
+	StoryStateRejected    StoryState = "rejected"
 )
 
+// AllStoryStates returns every story state in the order a story moves
+// through them, from the icebox to acceptance.
+func AllStoryStates() []StoryState {
+	return []StoryState{
+		StoryStateUnscheduled,
+		StoryStateUnstarted,
+		StoryStatePlanned,
+		StoryStateStarted,
+		StoryStateFinished,
+		StoryStateDelivered,
+		StoryStateRejected,
+		StoryStateAccepted,
+	}
+}
+
+func ParseStoryState(value string) (StoryState, error) {
+	state := StoryState(value)
+	if !state.Valid() {
+		return "", fmt.Errorf("unknown story state: %q", value)
+	}
+
+	return state, nil
+}
+
+func (s StoryState) Valid() bool {
+	for _, state := range AllStoryStates() {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalStrict is like json.Unmarshal but fails if the JSON holds a
+// story type or state this package does not know about.
+func UnmarshalStrict(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	return checkKnownValues(reflect.ValueOf(v))
+}
+
+func checkKnownValues(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return checkKnownValues(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := checkKnownValues(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := checkKnownValues(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if err := checkKnownValues(v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		switch value := v.Interface().(type) {
+		case StoryType:
+			if value != "" && !value.Valid() {
+				_, err := ParseStoryType(string(value))
+				return err
+			}
+		case StoryState:
+			if value != "" && !value.Valid() {
+				_, err := ParseStoryState(string(value))
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 type Activity struct {
 	Kind             string        `json:"kind"`
 	GUID             string        `json:"guid"`
@@ -158,7 +350,124 @@ type Activity struct {
 	OccurredAt       time.Time     `json:"occurred_at"`
 }
 
+type Iteration struct {
+	Kind         string    `json:"kind,omitempty"`
+	Number       int       `json:"number"`
+	ProjectID    int       `json:"project_id,omitempty"`
+	Length       int       `json:"length,omitempty"`
+	TeamStrength float64   `json:"team_strength,omitempty"`
+	StoryIDs     []int     `json:"story_ids,omitempty"`
+	Stories      []Story   `json:"stories,omitempty"`
+	Start        time.Time `json:"start"`
+	Finish       time.Time `json:"finish"`
+	Velocity     float64   `json:"velocity,omitempty"`
+	Points       int       `json:"points,omitempty"`
+}
+
+type IterationScope string
+
+const (
+	IterationScopeDone           IterationScope = "done"
+	IterationScopeCurrent        IterationScope = "current"
+	IterationScopeBacklog        IterationScope = "backlog"
+	IterationScopeCurrentBacklog IterationScope = "current_backlog"
+)
+
+type StoryTransition struct {
+	Kind           string     `json:"kind,omitempty"`
+	State          StoryState `json:"state"`
+	StoryID        int        `json:"story_id"`
+	ProjectID      int        `json:"project_id"`
+	ProjectVersion int        `json:"project_version"`
+	OccurredAt     time.Time  `json:"occurred_at"`
+	PerformedByID  int        `json:"performed_by_id"`
+	PerformedBy    *Person    `json:"performed_by,omitempty"`
+}
+
 type ProjectMembership struct {
-	ID     int `json:"id"`
-	Person Person
+	Kind      string `json:"kind,omitempty"`
+	ID        int    `json:"id,omitempty"`
+	ProjectID int    `json:"project_id,omitempty"`
+
+	Person       Person      `json:"person"`
+	Role         ProjectRole `json:"role,omitempty"`
+	ProjectColor string      `json:"project_color,omitempty"`
+	Favorite     bool        `json:"favorite,omitempty"`
+
+	WantsCommentNotificationEmails          bool `json:"wants_comment_notification_emails,omitempty"`
+	WillReceiveMentionNotificationsOrEmails bool `json:"will_receive_mention_notifications_or_emails,omitempty"`
+
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+type NewProjectMembership struct {
+	PersonID int         `json:"person_id,omitempty"`
+	Email    string      `json:"email,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	Initials string      `json:"initials,omitempty"`
+	Role     ProjectRole `json:"role,omitempty"`
+}
+
+type ProjectRole string
+
+const (
+	ProjectRoleOwner  ProjectRole = "owner"
+	ProjectRoleMember ProjectRole = "member"
+	ProjectRoleViewer ProjectRole = "viewer"
+)
+
+type Account struct {
+	Kind         string     `json:"kind,omitempty"`
+	ID           int        `json:"id,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	Plan         string     `json:"plan,omitempty"`
+	DaysLeft     int        `json:"days_left,omitempty"`
+	OverTheLimit bool       `json:"over_the_limit,omitempty"`
+	ProjectIDs   []int      `json:"project_ids,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+type AccountMembership struct {
+	Kind      string `json:"kind,omitempty"`
+	ID        int    `json:"id,omitempty"`
+	AccountID int    `json:"account_id,omitempty"`
+
+	Person Person `json:"person"`
+
+	Admin          bool `json:"admin"`
+	Owner          bool `json:"owner"`
+	ProjectCreator bool `json:"project_creator"`
+	Timekeeper     bool `json:"timekeeper"`
+	TimeEnterer    bool `json:"time_enterer"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type AccountPermissions struct {
+	Admin          bool `json:"admin"`
+	ProjectCreator bool `json:"project_creator"`
+	Timekeeper     bool `json:"timekeeper"`
+	TimeEnterer    bool `json:"time_enterer"`
+}
+
+type NewAccountMembership struct {
+	PersonID int    `json:"person_id,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Initials string `json:"initials,omitempty"`
+
+	AccountPermissions
+}
+
+type Workspace struct {
+	Kind       string `json:"kind,omitempty"`
+	ID         int    `json:"id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	PersonID   int    `json:"person_id,omitempty"`
+	ProjectIDs []int  `json:"project_ids,omitempty"`
 }
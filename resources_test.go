@@ -52,7 +52,7 @@ var _ = Describe("Story", func() {
 			{ID: 10, ProjectID: 99, Name: "some-label"},
 			{ID: 11, ProjectID: 99, Name: "some-other-label"},
 		}))
-		Expect(story.Estimate).To(Equal(3))
+		Expect(story.Estimate).To(Equal(tracker.Estimate(3)))
 		Expect(*story.CreatedAt).To(Equal(time.Date(2015, 07, 20, 22, 50, 50, 0, time.UTC)))
 		Expect(*story.UpdatedAt).To(Equal(time.Date(2015, 07, 20, 22, 51, 50, 0, time.UTC)))
 		Expect(*story.AcceptedAt).To(Equal(time.Date(2015, 07, 20, 22, 52, 50, 0, time.UTC)))
@@ -61,6 +61,36 @@ var _ = Describe("Story", func() {
 			{ID: 13, Description: "some other blocker"},
 		}))
 	})
+
+	It("tells a zero-point estimate from no estimate", func() {
+		var estimated, unestimated tracker.Story
+		Expect(json.Unmarshal([]byte(`{"estimate": 0}`), &estimated)).To(Succeed())
+		Expect(json.Unmarshal([]byte(`{}`), &unestimated)).To(Succeed())
+
+		Expect(estimated.Estimate).To(Equal(tracker.Estimate(0)))
+		Expect(unestimated.Estimate).To(BeNil())
+		Expect(unestimated.Points()).To(BeZero())
+	})
+})
+
+var _ = Describe("Search Results", func() {
+	It("has attributes", func() {
+		var results tracker.SearchResults
+		reader := strings.NewReader(Fixture("search.json"))
+		err := json.NewDecoder(reader).Decode(&results)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(results.Query).To(Equal(`label:"rebel bases"`))
+		Expect(results.Stories.TotalHits).To(Equal(2))
+		Expect(results.Stories.TotalHitsWithDone).To(Equal(2))
+		Expect(results.Stories.Stories[0].Name).To(Equal("Interrogate Leia Organa"))
+
+		epic := results.Epics.Epics[0]
+		Expect(epic.ID).To(Equal(5))
+		Expect(epic.Name).To(Equal("Find the rebel base"))
+		Expect(epic.Label.ID).To(Equal(2008))
+		Expect(*epic.CreatedAt).To(Equal(time.Date(2015, 07, 20, 22, 50, 50, 0, time.UTC)))
+	})
 })
 
 var _ = Describe("Task", func() {
@@ -92,6 +122,22 @@ var _ = Describe("Activity", func() {
 	})
 })
 
+var _ = Describe("Story Transition", func() {
+	It("has attributes", func() {
+		var transitions []tracker.StoryTransition
+		reader := strings.NewReader(Fixture("story_transitions.json"))
+		err := json.NewDecoder(reader).Decode(&transitions)
+		Expect(err).NotTo(HaveOccurred())
+		transition := transitions[0]
+
+		Expect(transition.State).To(Equal(tracker.StoryStateStarted))
+		Expect(transition.StoryID).To(Equal(560))
+		Expect(transition.ProjectVersion).To(Equal(1001))
+		Expect(transition.OccurredAt).To(Equal(time.Date(2015, 07, 20, 22, 51, 0, 0, time.UTC)))
+		Expect(transition.PerformedByID).To(Equal(101))
+	})
+})
+
 var _ = Describe("Project Memberships", func() {
 	It("has attributes", func() {
 		var projectMemberships []tracker.ProjectMembership
@@ -106,5 +152,106 @@ var _ = Describe("Project Memberships", func() {
 		Expect(membership.Person.Email).To(Equal("emperor@galacticrepublic.gov"))
 		Expect(membership.Person.Initials).To(Equal("EP"))
 		Expect(membership.Person.Username).To(Equal("palpatine"))
+		Expect(membership.ProjectID).To(Equal(99))
+		Expect(membership.Role).To(Equal(tracker.ProjectRoleOwner))
+		Expect(membership.ProjectColor).To(Equal("8100ea"))
+		Expect(membership.WillReceiveMentionNotificationsOrEmails).To(BeTrue())
+		Expect(*membership.LastViewedAt).To(Equal(time.Date(2016, 9, 13, 12, 0, 10, 0, time.UTC)))
+	})
+})
+
+var _ = Describe("Account", func() {
+	It("has attributes", func() {
+		var accounts []tracker.Account
+		reader := strings.NewReader(Fixture("accounts.json"))
+		err := json.NewDecoder(reader).Decode(&accounts)
+		Expect(err).NotTo(HaveOccurred())
+
+		account := accounts[0]
+		Expect(account.ID).To(Equal(100))
+		Expect(account.Name).To(Equal("Galactic Empire"))
+		Expect(account.Plan).To(Equal("Unlimited"))
+		Expect(account.ProjectIDs).To(Equal([]int{98, 99}))
+	})
+})
+
+var _ = Describe("Account Memberships", func() {
+	It("has attributes", func() {
+		var memberships []tracker.AccountMembership
+		reader := strings.NewReader(Fixture("account_memberships.json"))
+		err := json.NewDecoder(reader).Decode(&memberships)
+		Expect(err).NotTo(HaveOccurred())
+
+		membership := memberships[1]
+		Expect(membership.ID).To(Equal(101))
+		Expect(membership.AccountID).To(Equal(100))
+		Expect(membership.Person.Username).To(Equal("vader"))
+		Expect(membership.Admin).To(BeFalse())
+		Expect(membership.ProjectCreator).To(BeTrue())
+		Expect(membership.Timekeeper).To(BeFalse())
+	})
+})
+
+var _ = Describe("StoryType", func() {
+	It("knows which story types are valid", func() {
+		Expect(tracker.StoryTypeBug.Valid()).To(BeTrue())
+		Expect(tracker.StoryType("feture").Valid()).To(BeFalse())
+	})
+
+	It("can be parsed", func() {
+		storyType, err := tracker.ParseStoryType("chore")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(storyType).To(Equal(tracker.StoryTypeChore))
+
+		_, err = tracker.ParseStoryType("feture")
+		Expect(err).To(MatchError(`unknown story type: "feture"`))
+	})
+})
+
+var _ = Describe("StoryState", func() {
+	It("knows which story states are valid", func() {
+		Expect(tracker.StoryStateDelivered.Valid()).To(BeTrue())
+		Expect(tracker.StoryState("done").Valid()).To(BeFalse())
+	})
+
+	It("can be parsed", func() {
+		state, err := tracker.ParseStoryState("started")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(tracker.StoryStateStarted))
+
+		_, err = tracker.ParseStoryState("done")
+		Expect(err).To(MatchError(`unknown story state: "done"`))
+	})
+
+	It("lists every state in workflow order", func() {
+		states := tracker.AllStoryStates()
+		Expect(states[0]).To(Equal(tracker.StoryStateUnscheduled))
+		Expect(states[len(states)-1]).To(Equal(tracker.StoryStateAccepted))
+		for _, state := range states {
+			Expect(state.Valid()).To(BeTrue())
+		}
+	})
+})
+
+var _ = Describe("Strict unmarshalling", func() {
+	It("accepts unknown story types and states by default", func() {
+		var story tracker.Story
+		err := json.Unmarshal([]byte(`{"story_type":"epic","current_state":"done"}`), &story)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(story.Type).To(Equal(tracker.StoryType("epic")))
+		Expect(story.State).To(Equal(tracker.StoryState("done")))
+	})
+
+	It("rejects unknown story types and states when strict", func() {
+		var story tracker.Story
+		err := tracker.UnmarshalStrict([]byte(`{"story_type":"epic"}`), &story)
+		Expect(err).To(MatchError(`unknown story type: "epic"`))
+
+		var stories []tracker.Story
+		err = tracker.UnmarshalStrict([]byte(`[{"id": 1}, {"current_state":"done"}]`), &stories)
+		Expect(err).To(MatchError(`unknown story state: "done"`))
+
+		err = tracker.UnmarshalStrict([]byte(Fixture("story.json")), &story)
+		Expect(err).NotTo(HaveOccurred())
 	})
 })
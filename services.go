@@ -0,0 +1,163 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker
+
+// ProjectService reads a project and its planning data.
+type ProjectService interface {
+	Project() (*Project, error)
+	Labels(query LabelsQuery) ([]Label, Pagination, error)
+	Iterations(query IterationsQuery) ([]Iteration, Pagination, error)
+	Search(query SearchQuery) (SearchResults, error)
+	Transitions(query StoryTransitionsQuery) ([]StoryTransition, Pagination, error)
+}
+
+// StoryService reads and changes the stories in a project.
+type StoryService interface {
+	Story(storyID int) (Story, error)
+	StoryWithQuery(storyID int, query StoryQuery) (Story, error)
+	StoryWithVersion(storyID int) (Story, int, error)
+	Stories(query StoriesQuery) ([]Story, Pagination, error)
+	StoriesByIDs(storyIDs []int) ([]Story, error)
+	StoryActivity(storyID int, query ActivityQuery) ([]Activity, error)
+	StoryTransitions(storyID int) ([]StoryTransition, error)
+	CreateStory(story NewStory) (Story, error)
+	UpdateStory(story Story) (Story, error)
+	UpdateStoryLabels(storyID int, labels []string) (Story, error)
+	ModifyStory(storyID int, modify func(*Story) error) (Story, error)
+	DeleteStory(storyID int) error
+	MoveStory(storyID int, position StoryPosition) (Story, error)
+	MoveStories(storyIDs []int, position StoryPosition) ([]Story, error)
+	MoveStoryToProject(storyID int, projectID int) (Story, error)
+	StartStory(storyID int) (Story, error)
+	FinishStory(storyID int) (Story, error)
+	DeliverStory(storyID int) error
+	DeliverStoryWithComment(storyID int, comment string) error
+	AcceptStory(storyID int) (Story, error)
+	RejectStory(storyID int, reason string) (Story, error)
+	TransitionStory(storyID int, state StoryState) (Story, error)
+	CreateBlocker(storyID int, blocker Blocker) (Blocker, error)
+	BulkCreateStories(stories []NewStory, options BulkOptions) ([]BulkResult, error)
+	BulkUpdateStories(stories []Story, options BulkOptions) ([]BulkResult, error)
+	BulkDeleteStories(storyIDs []int, options BulkOptions) ([]BulkResult, error)
+}
+
+// TaskService reads and adds the tasks on a project's stories.
+type TaskService interface {
+	StoryTasks(storyID int, query TaskQuery) ([]Task, error)
+	CreateTask(storyID int, task Task) (Task, error)
+}
+
+// CommentService reads and adds the comments on a project's stories.
+type CommentService interface {
+	StoryComments(storyID int, query CommentsQuery) ([]Comment, error)
+	CreateComment(storyID int, comment Comment) (Comment, error)
+}
+
+// MembershipService manages who belongs to a project.
+type MembershipService interface {
+	ProjectMemberships() ([]ProjectMembership, error)
+	AddMember(membership NewProjectMembership) (ProjectMembership, error)
+	UpdateMemberRole(membershipID int, role ProjectRole) (ProjectMembership, error)
+	RemoveMember(membershipID int) error
+}
+
+// ProjectAPI combines the services a ProjectClient provides, for code that
+// would rather depend on an interface.
+type ProjectAPI interface {
+	ProjectService
+	StoryService
+	TaskService
+	CommentService
+	MembershipService
+}
+
+// UserService reads the authenticated user and their accounts.
+type UserService interface {
+	Me() (Me, error)
+	Accounts() ([]Account, error)
+	Account(accountID int) (Account, error)
+}
+
+// WorkspaceService manages the authenticated user's workspaces.
+type WorkspaceService interface {
+	Workspaces() ([]Workspace, error)
+	Workspace(workspaceID int) (Workspace, error)
+	CreateWorkspace(workspace Workspace) (Workspace, error)
+	UpdateWorkspace(workspace Workspace) (Workspace, error)
+	SetWorkspaceProjects(workspaceID int, projectIDs []int) (Workspace, error)
+	DeleteWorkspace(workspaceID int) error
+}
+
+// AccountAPI manages an account's members and lists its projects, as an
+// AccountClient does.
+type AccountAPI interface {
+	Memberships() ([]AccountMembership, error)
+	AddMember(membership NewAccountMembership) (AccountMembership, error)
+	UpdateMember(personID int, permissions AccountPermissions) (AccountMembership, error)
+	RemoveMember(personID int) error
+	Projects() ([]Project, error)
+}
+
+// ClientAPI is what a Client provides, with the project and account
+// clients it makes returned as interfaces. Use API to get one for a Client.
+type ClientAPI interface {
+	UserService
+	WorkspaceService
+
+	Story(storyID int) (Story, error)
+	StoryWithQuery(storyID int, query StoryQuery) (Story, error)
+	StoriesByIDs(storyIDs []int) map[int]StoryResult
+
+	InProject(projectID int) ProjectAPI
+	InAccount(accountID int) AccountAPI
+	InWorkspace(workspaceID int) ([]ProjectAPI, error)
+}
+
+// API returns client as a ClientAPI.
+func API(client *Client) ClientAPI {
+	return clientAPI{client}
+}
+
+type clientAPI struct {
+	*Client
+}
+
+func (c clientAPI) InProject(projectID int) ProjectAPI {
+	return c.Client.InProject(projectID)
+}
+
+func (c clientAPI) InAccount(accountID int) AccountAPI {
+	return c.Client.InAccount(accountID)
+}
+
+func (c clientAPI) InWorkspace(workspaceID int) ([]ProjectAPI, error) {
+	projects, err := c.Client.InWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	apis := make([]ProjectAPI, 0, len(projects))
+	for _, project := range projects {
+		apis = append(apis, project)
+	}
+
+	return apis, nil
+}
+
+var (
+	_ ProjectAPI       = ProjectClient{}
+	_ AccountAPI       = AccountClient{}
+	_ UserService      = Client{}
+	_ WorkspaceService = Client{}
+)
@@ -0,0 +1,144 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes a finished request to Tracker.
+type RequestInfo struct {
+	// Operation names the client method which made the request, such as
+	// "tracker.Stories".
+	Operation string
+	Method    string
+	Path      string
+	ProjectID int
+
+	StatusCode int
+	ErrorCode  string
+	Err        error
+	Duration   time.Duration
+}
+
+// Tracer starts a span for each request. The context it returns is sent
+// down the middleware chain, so middleware can add the span's headers.
+type Tracer interface {
+	StartSpan(ctx context.Context, operation string, projectID int) (context.Context, Span)
+}
+
+type Span interface {
+	End(info RequestInfo)
+}
+
+// Metrics is told about every finished request, for adapters to feed a
+// latency histogram and request and error counters.
+type Metrics interface {
+	ObserveRequest(info RequestInfo)
+}
+
+// Telemetry adapts the client to a tracing and metrics system. Either
+// field may be nil, in which case nothing is reported.
+type Telemetry struct {
+	Tracer  Tracer
+	Metrics Metrics
+}
+
+type NoopTracer struct{}
+
+func (NoopTracer) StartSpan(ctx context.Context, operation string, projectID int) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) End(RequestInfo) {}
+
+type NoopMetrics struct{}
+
+func (NoopMetrics) ObserveRequest(RequestInfo) {}
+
+type telemetryDoer struct {
+	next      Doer
+	telemetry Telemetry
+}
+
+func (d telemetryDoer) Do(request *http.Request) (*http.Response, error) {
+	tracer := d.telemetry.Tracer
+	if tracer == nil {
+		tracer = NoopTracer{}
+	}
+	metrics := d.telemetry.Metrics
+	if metrics == nil {
+		metrics = NoopMetrics{}
+	}
+
+	info := RequestInfo{
+		Operation: operationFromContext(request.Context()),
+		Method:    request.Method,
+		Path:      request.URL.Path,
+		ProjectID: projectIDFromPath(request.URL.Path),
+	}
+
+	ctx, span := tracer.StartSpan(request.Context(), info.Operation, info.ProjectID)
+
+	start := time.Now()
+	response, err := d.next.Do(request.WithContext(ctx))
+	info.Duration = time.Since(start)
+
+	if err != nil {
+		info.Err = err
+	} else {
+		info.StatusCode = response.StatusCode
+		if response.StatusCode >= http.StatusBadRequest {
+			info.ErrorCode, _ = readTrackerError(response)
+		}
+	}
+
+	span.End(info)
+	metrics.ObserveRequest(info)
+
+	return response, err
+}
+
+type operationKey struct{}
+
+// withOperation names the client method the requests made with ctx are
+// for, such as "tracker.StartStory".
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// operationFromContext returns the operation named by withOperation, or
+// "tracker.Request" for requests made outside the client methods.
+func operationFromContext(ctx context.Context) string {
+	if operation, ok := ctx.Value(operationKey{}).(string); ok {
+		return operation
+	}
+	return "tracker.Request"
+}
+
+func projectIDFromPath(path string) int {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, "/services/v5"), "/"), "/")
+	if len(parts) < 2 || parts[0] != "projects" {
+		return 0
+	}
+
+	id, _ := strconv.Atoi(parts[1])
+	return id
+}
@@ -0,0 +1,870 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trackertest provides an in-memory fake of the Tracker v5 API for
+// testing code which uses the tracker package without network access.
+//
+//	server := trackertest.NewServer()
+//	defer server.Close()
+//
+//	project := server.AddProject(tracker.Project{Name: "Death Star"})
+//	client := server.Client()
+//	stories, _, err := client.InProject(project.ID).Stories(tracker.StoriesQuery{})
+package trackertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deoxxa/go-tracker"
+)
+
+// DefaultToken is the API token the server accepts unless Token is changed.
+const DefaultToken = "trackertest-token"
+
+type Server struct {
+	*httptest.Server
+
+	// Token is the API token requests must carry. An empty token lets
+	// every request through.
+	Token string
+
+	// Now is used to timestamp changes. It defaults to time.Now.
+	Now func() time.Time
+
+	mutex    sync.Mutex
+	nextID   int
+	me       tracker.Me
+	projects map[int]*project
+}
+
+type project struct {
+	tracker.Project
+
+	stories     []tracker.Story
+	tasks       map[int][]tracker.Task
+	comments    map[int][]tracker.Comment
+	blockers    map[int][]tracker.Blocker
+	labels      []tracker.Label
+	memberships []tracker.ProjectMembership
+	activity    []tracker.Activity
+}
+
+// NewServer starts a fake Tracker with no projects. Close it when done.
+func NewServer() *Server {
+	server := &Server{
+		Token:    DefaultToken,
+		Now:      time.Now,
+		nextID:   1000,
+		projects: map[int]*project{},
+		me: tracker.Me{
+			ID:       100,
+			Username: "trackertest",
+			Name:     "Tracker Test",
+			Initials: "TT",
+			Email:    "trackertest@example.com",
+		},
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+
+	return server
+}
+
+// Client returns a client which talks to the server with its token.
+func (s *Server) Client() *tracker.Client {
+	client := tracker.NewClient(s.Token)
+	client.SetBaseURL(s.URL)
+	return client
+}
+
+// SetMe sets the person returned as the authenticated user.
+func (s *Server) SetMe(me tracker.Me) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.me = me
+}
+
+// AddProject adds a project, giving it an ID if it has none.
+func (s *Server) AddProject(p tracker.Project) tracker.Project {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if p.ID == 0 {
+		p.ID = s.newID()
+	}
+	p.Kind = "project"
+	if p.Version == 0 {
+		p.Version = 1
+	}
+
+	s.projects[p.ID] = &project{
+		Project:  p,
+		tasks:    map[int][]tracker.Task{},
+		comments: map[int][]tracker.Comment{},
+		blockers: map[int][]tracker.Blocker{},
+	}
+
+	return p
+}
+
+// AddStory adds a story to the bottom of a project's backlog, giving it an
+// ID if it has none.
+func (s *Server) AddStory(projectID int, story tracker.Story) tracker.Story {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p := s.mustProject(projectID)
+	story = s.prepareStory(p, story)
+	p.stories = append(p.stories, story)
+
+	return story
+}
+
+// AddLabel adds a label to a project, giving it an ID if it has none.
+func (s *Server) AddLabel(projectID int, label tracker.Label) tracker.Label {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.label(s.mustProject(projectID), label.Name)
+}
+
+// AddMembership adds a member to a project, giving the membership and the
+// person IDs if they have none.
+func (s *Server) AddMembership(projectID int, membership tracker.ProjectMembership) tracker.ProjectMembership {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p := s.mustProject(projectID)
+	membership = s.prepareMembership(p, membership)
+	p.memberships = append(p.memberships, membership)
+
+	return membership
+}
+
+// Stories returns the stories of a project in backlog order.
+func (s *Server) Stories(projectID int) []tracker.Story {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p := s.mustProject(projectID)
+	return append([]tracker.Story(nil), p.stories...)
+}
+
+// Tasks returns the tasks of a story.
+func (s *Server) Tasks(projectID int, storyID int) []tracker.Task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]tracker.Task(nil), s.mustProject(projectID).tasks[storyID]...)
+}
+
+// Comments returns the comments on a story.
+func (s *Server) Comments(projectID int, storyID int) []tracker.Comment {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]tracker.Comment(nil), s.mustProject(projectID).comments[storyID]...)
+}
+
+func (s *Server) mustProject(projectID int) *project {
+	p, ok := s.projects[projectID]
+	if !ok {
+		panic(fmt.Sprintf("trackertest: no project %d", projectID))
+	}
+	return p
+}
+
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) now() *time.Time {
+	now := s.Now().UTC().Truncate(time.Second)
+	return &now
+}
+
+func (s *Server) prepareStory(p *project, story tracker.Story) tracker.Story {
+	if story.ID == 0 {
+		story.ID = s.newID()
+	}
+	story.ProjectID = p.ID
+	story.URL = fmt.Sprintf("%s/story/show/%d", s.URL, story.ID)
+	if story.Type == "" {
+		story.Type = tracker.StoryTypeFeature
+	}
+	if story.State == "" {
+		story.State = tracker.StoryStateUnscheduled
+	}
+	if story.CreatedAt == nil {
+		story.CreatedAt = s.now()
+	}
+	if story.UpdatedAt == nil {
+		story.UpdatedAt = story.CreatedAt
+	}
+	if story.State == tracker.StoryStateAccepted && story.AcceptedAt == nil {
+		story.AcceptedAt = story.UpdatedAt
+	}
+	for i, label := range story.Labels {
+		story.Labels[i] = s.label(p, label.Name)
+	}
+
+	return story
+}
+
+func (s *Server) prepareMembership(p *project, membership tracker.ProjectMembership) tracker.ProjectMembership {
+	if membership.ID == 0 {
+		membership.ID = s.newID()
+	}
+	if membership.Person.ID == 0 {
+		membership.Person.ID = s.newID()
+	}
+	if membership.Role == "" {
+		membership.Role = tracker.ProjectRoleMember
+	}
+	membership.Kind = "project_membership"
+	membership.ProjectID = p.ID
+	membership.CreatedAt = s.now()
+	membership.UpdatedAt = membership.CreatedAt
+
+	return membership
+}
+
+// label finds the project's label with the name, creating it if needed.
+func (s *Server) label(p *project, name string) tracker.Label {
+	for _, label := range p.labels {
+		if label.Name == name {
+			return label
+		}
+	}
+
+	label := tracker.Label{
+		Kind:      "label",
+		ID:        s.newID(),
+		ProjectID: p.ID,
+		Name:      name,
+		CreatedAt: s.now(),
+	}
+	label.UpdatedAt = label.CreatedAt
+	p.labels = append(p.labels, label)
+
+	return label
+}
+
+// record bumps the project's version and adds an activity entry for a
+// change to a story. newValues holds only the fields which changed, so that
+// current_state appears only when the story moved through the workflow.
+func (s *Server) record(p *project, story tracker.Story, kind string, highlight string, newValues map[string]interface{}) {
+	p.Version++
+
+	change := map[string]interface{}{
+		"kind":        "story",
+		"change_type": strings.TrimSuffix(strings.TrimPrefix(kind, "story_"), "_activity"),
+		"id":          story.ID,
+	}
+	if newValues != nil {
+		change["new_values"] = newValues
+	}
+
+	p.activity = append([]tracker.Activity{{
+		Kind:           kind,
+		GUID:           fmt.Sprintf("%d_%d", p.ID, p.Version),
+		ProjectVersion: p.Version,
+		Message:        fmt.Sprintf("%s %s this %s", s.me.Name, highlight, story.Type),
+		Highlight:      highlight,
+		Changes:        []interface{}{change},
+		PrimaryResources: []interface{}{map[string]interface{}{
+			"kind": "story",
+			"id":   story.ID,
+			"name": story.Name,
+		}},
+		Project:     map[string]interface{}{"kind": "project", "id": p.ID, "name": p.Name},
+		PerformedBy: map[string]interface{}{"kind": "person", "id": s.me.ID, "name": s.me.Name},
+		OccurredAt:  *s.now(),
+	}}, p.activity...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.Token != "" && r.Header.Get("X-TrackerToken") != s.Token {
+		writeError(w, http.StatusUnauthorized, "invalid_authentication", "Invalid authentication credentials were presented.")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/services/v5")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case path == "/me" && r.Method == "GET":
+		writeJSON(w, http.StatusOK, struct {
+			tracker.Me
+			APIToken string `json:"api_token,omitempty"`
+		}{s.me, s.Token})
+	case len(parts) == 2 && parts[0] == "stories" && r.Method == "GET":
+		s.serveStoryByID(w, parts[1])
+	case len(parts) >= 2 && parts[0] == "projects":
+		projectID, err := strconv.Atoi(parts[1])
+		p, ok := s.projects[projectID]
+		if err != nil || !ok {
+			writeNotFound(w)
+			return
+		}
+
+		if r.Method != "GET" {
+			if expected := r.Header.Get("X-Tracker-Project-Version"); expected != "" && expected != strconv.Itoa(p.Version) {
+				w.Header().Set("X-Tracker-Project-Version", strconv.Itoa(p.Version))
+				writeError(w, http.StatusConflict, "version_conflict", "The project has changed since the version given.")
+				return
+			}
+		}
+
+		s.serveProject(versionWriter{w, p}, r, p, parts[2:])
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (s *Server) serveStoryByID(w http.ResponseWriter, id string) {
+	storyID, err := strconv.Atoi(id)
+	if err != nil {
+		writeNotFound(w)
+		return
+	}
+
+	for _, projectID := range s.sortedProjectIDs() {
+		p := s.projects[projectID]
+		if i := p.storyIndex(storyID); i >= 0 {
+			writeJSON(w, http.StatusOK, p.stories[i])
+			return
+		}
+	}
+
+	writeNotFound(w)
+}
+
+func (s *Server) serveProject(w http.ResponseWriter, r *http.Request, p *project, parts []string) {
+	route := r.Method + " " + strings.Join(routeParts(parts), "/")
+
+	switch route {
+	case "GET ":
+		writeJSON(w, http.StatusOK, p.Project)
+	case "GET stories":
+		s.listStories(w, r, p)
+	case "POST stories":
+		s.createStory(w, r, p)
+	case "GET stories/:id":
+		s.withStory(w, p, parts[1], func(i int) {
+			writeJSON(w, http.StatusOK, p.stories[i])
+		})
+	case "PUT stories/:id":
+		s.withStory(w, p, parts[1], func(i int) {
+			s.updateStory(w, r, p, i)
+		})
+	case "DELETE stories/:id":
+		s.withStory(w, p, parts[1], func(i int) {
+			story := p.stories[i]
+			p.stories = append(p.stories[:i], p.stories[i+1:]...)
+			s.record(p, story, "story_delete_activity", "deleted", nil)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	case "GET stories/:id/tasks":
+		s.withStory(w, p, parts[1], func(i int) {
+			writeJSON(w, http.StatusOK, nonNil(p.tasks[p.stories[i].ID]))
+		})
+	case "POST stories/:id/tasks":
+		s.withStory(w, p, parts[1], func(i int) {
+			var task tracker.Task
+			if !readJSON(w, r, &task) {
+				return
+			}
+			task.ID = s.newID()
+			task.StoryID = p.stories[i].ID
+			task.CreatedAt = s.now()
+			task.UpdatedAt = task.CreatedAt
+			if task.Position == 0 {
+				task.Position = len(p.tasks[task.StoryID]) + 1
+			}
+			p.tasks[task.StoryID] = append(p.tasks[task.StoryID], task)
+			p.Version++
+			writeJSON(w, http.StatusOK, task)
+		})
+	case "GET stories/:id/comments":
+		s.withStory(w, p, parts[1], func(i int) {
+			writeJSON(w, http.StatusOK, nonNil(p.comments[p.stories[i].ID]))
+		})
+	case "POST stories/:id/comments":
+		s.withStory(w, p, parts[1], func(i int) {
+			var comment tracker.Comment
+			if !readJSON(w, r, &comment) {
+				return
+			}
+			comment.ID = s.newID()
+			comment.StoryID = p.stories[i].ID
+			comment.PersonID = s.me.ID
+			comment.CreatedAt = s.now()
+			comment.UpdatedAt = comment.CreatedAt
+			p.comments[comment.StoryID] = append(p.comments[comment.StoryID], comment)
+			p.Version++
+			writeJSON(w, http.StatusOK, comment)
+		})
+	case "GET stories/:id/blockers":
+		s.withStory(w, p, parts[1], func(i int) {
+			writeJSON(w, http.StatusOK, nonNil(p.blockers[p.stories[i].ID]))
+		})
+	case "POST stories/:id/blockers":
+		s.withStory(w, p, parts[1], func(i int) {
+			var blocker tracker.Blocker
+			if !readJSON(w, r, &blocker) {
+				return
+			}
+			blocker.ID = s.newID()
+			storyID := p.stories[i].ID
+			p.blockers[storyID] = append(p.blockers[storyID], blocker)
+			p.stories[i].Blockers = append(p.stories[i].Blockers, blocker)
+			p.Version++
+			writeJSON(w, http.StatusOK, blocker)
+		})
+	case "GET stories/:id/activity":
+		s.withStory(w, p, parts[1], func(i int) {
+			var activities []tracker.Activity
+			for _, activity := range p.activity {
+				if activityStoryID(activity) == p.stories[i].ID {
+					activities = append(activities, activity)
+				}
+			}
+			writePage(w, r, activities)
+		})
+	case "GET activity":
+		writePage(w, r, p.activity)
+	case "GET labels":
+		writePage(w, r, p.labels)
+	case "GET memberships":
+		writeJSON(w, http.StatusOK, nonNil(p.memberships))
+	case "POST memberships":
+		var body tracker.NewProjectMembership
+		if !readJSON(w, r, &body) {
+			return
+		}
+		membership := s.prepareMembership(p, tracker.ProjectMembership{
+			Person: tracker.Person{
+				ID:       body.PersonID,
+				Email:    body.Email,
+				Name:     body.Name,
+				Initials: body.Initials,
+			},
+			Role: body.Role,
+		})
+		p.memberships = append(p.memberships, membership)
+		p.Version++
+		writeJSON(w, http.StatusOK, membership)
+	case "PUT memberships/:id", "DELETE memberships/:id":
+		membershipID, _ := strconv.Atoi(parts[1])
+		for i, membership := range p.memberships {
+			if membership.ID != membershipID {
+				continue
+			}
+
+			p.Version++
+			if r.Method == "DELETE" {
+				p.memberships = append(p.memberships[:i], p.memberships[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			var body struct {
+				Role tracker.ProjectRole `json:"role"`
+			}
+			if !readJSON(w, r, &body) {
+				return
+			}
+			if body.Role != "" {
+				p.memberships[i].Role = body.Role
+			}
+			p.memberships[i].UpdatedAt = s.now()
+			writeJSON(w, http.StatusOK, p.memberships[i])
+			return
+		}
+		writeNotFound(w)
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (s *Server) listStories(w http.ResponseWriter, r *http.Request, p *project) {
+	query := r.URL.Query()
+
+	matches, err := parseFilter(query.Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	var stories []tracker.Story
+	for _, story := range p.stories {
+		if state := query.Get("with_state"); state != "" && string(story.State) != state {
+			continue
+		}
+		if label := query.Get("with_label"); label != "" && !hasLabel(story, label) {
+			continue
+		}
+		if !matches(story) {
+			continue
+		}
+		stories = append(stories, story)
+	}
+
+	writePage(w, r, stories)
+}
+
+// parseFilter understands the id:, state:, type:, label: and includedone:
+// terms of Tracker's search syntax. Other terms, quoted values and groups
+// are rejected rather than ignored so that tests do not pass by accident.
+func parseFilter(filter string) (func(tracker.Story) bool, error) {
+	var checks []func(tracker.Story) bool
+
+	for _, term := range strings.Fields(filter) {
+		parts := strings.SplitN(term, ":", 2)
+		if len(parts) != 2 || parts[1] == "" || strings.ContainsAny(parts[1], `"()`) {
+			return nil, fmt.Errorf("unsupported filter term %q", term)
+		}
+
+		switch parts[0] {
+		case "id":
+			ids := map[int]bool{}
+			for _, value := range strings.Split(parts[1], ",") {
+				id, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid story id %q", value)
+				}
+				ids[id] = true
+			}
+			checks = append(checks, func(story tracker.Story) bool {
+				return ids[story.ID]
+			})
+		case "state":
+			checks = append(checks, func(story tracker.Story) bool {
+				return string(story.State) == parts[1]
+			})
+		case "type":
+			checks = append(checks, func(story tracker.Story) bool {
+				return string(story.Type) == parts[1]
+			})
+		case "includedone":
+			// Done stories are never left out here.
+		case "label":
+			checks = append(checks, func(story tracker.Story) bool {
+				return hasLabel(story, parts[1])
+			})
+		default:
+			return nil, fmt.Errorf("unsupported filter term %q", term)
+		}
+	}
+
+	return func(story tracker.Story) bool {
+		for _, check := range checks {
+			if !check(story) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func (s *Server) createStory(w http.ResponseWriter, r *http.Request, p *project) {
+	var body tracker.NewStory
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	story := s.prepareStory(p, tracker.Story{
+		Name:        body.Name,
+		Description: body.Description,
+		Type:        body.Type,
+		State:       body.State,
+		Labels:      body.Labels,
+		OwnerIDs:    body.OwnerIDs,
+	})
+	p.stories = append(p.stories, story)
+
+	for _, task := range body.Tasks {
+		task.ID = s.newID()
+		task.StoryID = story.ID
+		p.tasks[story.ID] = append(p.tasks[story.ID], task)
+	}
+
+	s.record(p, story, "story_create_activity", "added", map[string]interface{}{"current_state": story.State})
+	writeJSON(w, http.StatusOK, story)
+}
+
+func (s *Server) updateStory(w http.ResponseWriter, r *http.Request, p *project, i int) {
+	var changes map[string]json.RawMessage
+	if !readJSON(w, r, &changes) {
+		return
+	}
+
+	story := p.stories[i]
+	previousState := story.State
+
+	// Labels may be given by name, as UpdateStoryLabels does.
+	if raw, ok := changes["labels"]; ok {
+		var names []string
+		if json.Unmarshal(raw, &names) == nil {
+			story.Labels = nil
+			for _, name := range names {
+				story.Labels = append(story.Labels, tracker.Label{Name: name})
+			}
+			delete(changes, "labels")
+		}
+	}
+
+	var position tracker.StoryPosition
+	var targetProjectID int
+	for key, target := range map[string]*int{"before_id": &position.BeforeID, "after_id": &position.AfterID, "project_id": &targetProjectID} {
+		if raw, ok := changes[key]; ok {
+			json.Unmarshal(raw, target)
+			delete(changes, key)
+		}
+	}
+	if _, ok := s.projects[targetProjectID]; targetProjectID != 0 && !ok {
+		writeNotFound(w)
+		return
+	}
+
+	remaining, _ := json.Marshal(changes)
+	if err := json.Unmarshal(remaining, &story); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	story.ID = p.stories[i].ID
+	story.UpdatedAt = s.now()
+	if story.State == tracker.StoryStateAccepted && previousState != tracker.StoryStateAccepted {
+		story.AcceptedAt = story.UpdatedAt
+	}
+	for j, label := range story.Labels {
+		story.Labels[j] = s.label(p, label.Name)
+	}
+
+	highlight := "edited"
+	newValues := map[string]interface{}{"updated_at": story.UpdatedAt}
+	if story.State != previousState {
+		highlight = string(story.State)
+		newValues["current_state"] = story.State
+	}
+
+	if target, ok := s.projects[targetProjectID]; ok && targetProjectID != p.ID {
+		p.stories = append(p.stories[:i], p.stories[i+1:]...)
+		story.ProjectID = target.ID
+		target.stories = append(target.stories, story)
+		target.Version++
+		s.record(p, story, "story_move_into_project_activity", "moved", map[string]interface{}{"project_id": target.ID})
+		writeJSON(w, http.StatusOK, story)
+		return
+	}
+
+	p.stories[i] = story
+	if position.BeforeID != 0 || position.AfterID != 0 {
+		p.move(i, position)
+	}
+
+	s.record(p, story, "story_update_activity", highlight, newValues)
+	writeJSON(w, http.StatusOK, story)
+}
+
+func (s *Server) withStory(w http.ResponseWriter, p *project, id string, found func(i int)) {
+	storyID, err := strconv.Atoi(id)
+	if err != nil {
+		writeNotFound(w)
+		return
+	}
+
+	i := p.storyIndex(storyID)
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+
+	found(i)
+}
+
+func (p *project) storyIndex(storyID int) int {
+	for i, story := range p.stories {
+		if story.ID == storyID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *project) move(i int, position tracker.StoryPosition) {
+	story := p.stories[i]
+	p.stories = append(p.stories[:i], p.stories[i+1:]...)
+
+	target := len(p.stories)
+	if j := p.storyIndex(position.BeforeID); position.BeforeID != 0 && j >= 0 {
+		target = j
+	}
+	if j := p.storyIndex(position.AfterID); position.AfterID != 0 && j >= 0 {
+		target = j + 1
+	}
+
+	p.stories = append(p.stories[:target], append([]tracker.Story{story}, p.stories[target:]...)...)
+}
+
+// routeParts replaces the IDs in a path with ":id" so that it can be
+// matched against a route.
+func routeParts(parts []string) []string {
+	route := make([]string, len(parts))
+	for i, part := range parts {
+		if i%2 == 1 {
+			route[i] = ":id"
+		} else {
+			route[i] = part
+		}
+	}
+	return route
+}
+
+func hasLabel(story tracker.Story, name string) bool {
+	for _, label := range story.Labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func activityStoryID(activity tracker.Activity) int {
+	for _, resource := range activity.PrimaryResources {
+		if values, ok := resource.(map[string]interface{}); ok && values["kind"] == "story" {
+			if id, ok := values["id"].(int); ok {
+				return id
+			}
+		}
+	}
+	return 0
+}
+
+func nonNil(items interface{}) interface{} {
+	switch items := items.(type) {
+	case []tracker.Task:
+		if items == nil {
+			return []tracker.Task{}
+		}
+	case []tracker.Comment:
+		if items == nil {
+			return []tracker.Comment{}
+		}
+	case []tracker.Blocker:
+		if items == nil {
+			return []tracker.Blocker{}
+		}
+	case []tracker.ProjectMembership:
+		if items == nil {
+			return []tracker.ProjectMembership{}
+		}
+	}
+	return items
+}
+
+// writePage writes the page of items asked for by the limit and offset
+// parameters, along with Tracker's pagination headers.
+func writePage(w http.ResponseWriter, r *http.Request, items interface{}) {
+	encoded, _ := json.Marshal(items)
+
+	var all []json.RawMessage
+	json.Unmarshal(encoded, &all)
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = len(all)
+	}
+
+	start := offset
+	if start > len(all) {
+		start = len(all)
+	}
+	end := start + limit
+	if end > len(all) {
+		end = len(all)
+	}
+	page := append([]json.RawMessage{}, all[start:end]...)
+
+	w.Header().Set("X-Tracker-Pagination-Total", strconv.Itoa(len(all)))
+	w.Header().Set("X-Tracker-Pagination-Offset", strconv.Itoa(offset))
+	w.Header().Set("X-Tracker-Pagination-Limit", strconv.Itoa(limit))
+	w.Header().Set("X-Tracker-Pagination-Returned", strconv.Itoa(len(page)))
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, object interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(object); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, object interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(object)
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "unfound_resource", "The object you tried to access could not be found.")
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, map[string]string{
+		"kind":  "error",
+		"code":  code,
+		"error": message,
+	})
+}
+
+// versionWriter reports the project's version, as it is after the request
+// has been handled, in the response headers.
+type versionWriter struct {
+	http.ResponseWriter
+	project *project
+}
+
+func (w versionWriter) WriteHeader(status int) {
+	w.Header().Set("X-Tracker-Project-Version", strconv.Itoa(w.project.Version))
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// sortedProjectIDs is used to make lookups across projects deterministic.
+func (s *Server) sortedProjectIDs() []int {
+	ids := make([]int, 0, len(s.projects))
+	for id := range s.projects {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,293 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package trackertest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/deoxxa/go-tracker"
+	"github.com/deoxxa/go-tracker/analytics"
+	"github.com/deoxxa/go-tracker/trackertest"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		server  *trackertest.Server
+		project tracker.Project
+		client  tracker.ProjectClient
+	)
+
+	BeforeEach(func() {
+		server = trackertest.NewServer()
+
+		project = server.AddProject(tracker.Project{Name: "Death Star"})
+		client = server.Client().InProject(project.ID)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("rejects requests with the wrong token", func() {
+		wrong := tracker.NewClient("wrong")
+		wrong.SetBaseURL(server.URL)
+
+		_, err := wrong.Me()
+		Expect(err).To(MatchError("invalid token"))
+	})
+
+	It("returns the authenticated user", func() {
+		me, err := server.Client().Me()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(me.Username).To(Equal("trackertest"))
+	})
+
+	It("returns the project", func() {
+		p, err := client.Project()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.Name).To(Equal("Death Star"))
+	})
+
+	It("fails for projects it doesn't know", func() {
+		_, err := server.Client().InProject(project.ID + 1).Project()
+		Expect(err).To(MatchError(ContainSubstring("404")))
+	})
+
+	It("creates, updates and deletes stories", func() {
+		story, err := client.CreateStory(tracker.NewStory{
+			Name:   "Build the superlaser",
+			Labels: []tracker.Label{{Name: "weapons"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(story.ID).NotTo(BeZero())
+		Expect(story.Type).To(Equal(tracker.StoryTypeFeature))
+		Expect(story.State).To(Equal(tracker.StoryStateUnscheduled))
+		Expect(story.Labels[0].ID).NotTo(BeZero())
+
+		story.Estimate = tracker.Estimate(3)
+		story.State = tracker.StoryStateStarted
+		updated, err := client.UpdateStory(story)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Points()).To(Equal(3))
+		Expect(updated.Name).To(Equal("Build the superlaser"))
+
+		found, err := server.Client().Story(story.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.State).To(Equal(tracker.StoryStateStarted))
+
+		activity, err := client.StoryActivity(story.ID, tracker.ActivityQuery{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(activity).To(HaveLen(2))
+		Expect(activity[0].Kind).To(Equal("story_update_activity"))
+		Expect(activity[0].Highlight).To(Equal("started"))
+
+		Expect(client.DeleteStory(story.ID)).To(Succeed())
+		Expect(server.Stories(project.ID)).To(BeEmpty())
+	})
+
+	It("reports a state change only when the state changed", func() {
+		story := server.AddStory(project.ID, tracker.Story{Name: "Superlaser", Type: tracker.StoryTypeChore, State: tracker.StoryStateUnstarted})
+
+		story.Name = "Superlaser, mark two"
+		_, err := client.UpdateStory(story)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.StartStory(story.ID)
+		Expect(err).NotTo(HaveOccurred())
+
+		activity, err := client.StoryActivity(story.ID, tracker.ActivityQuery{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(activity).To(HaveLen(2))
+
+		transitions := analytics.TransitionsFromActivity(activity)
+		Expect(transitions).To(HaveLen(1))
+		Expect(transitions[0].State).To(Equal(tracker.StoryStateStarted))
+	})
+
+	It("moves stories into other projects it knows", func() {
+		story := server.AddStory(project.ID, tracker.Story{Name: "Superlaser"})
+		other := server.AddProject(tracker.Project{Name: "Death Star II"})
+
+		_, err := client.MoveStoryToProject(story.ID, other.ID+1)
+		Expect(err).To(MatchError(ContainSubstring("404")))
+		Expect(server.Stories(project.ID)).To(HaveLen(1))
+
+		moved, err := client.MoveStoryToProject(story.ID, other.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(moved.ProjectID).To(Equal(other.ID))
+		Expect(server.Stories(project.ID)).To(BeEmpty())
+		Expect(server.Stories(other.ID)).To(HaveLen(1))
+	})
+
+	It("filters and paginates stories", func() {
+		for _, name := range []string{"Thermal exhaust port", "Superlaser", "Tractor beam"} {
+			server.AddStory(project.ID, tracker.Story{
+				Name:  name,
+				State: tracker.StoryStateStarted,
+			})
+		}
+		server.AddStory(project.ID, tracker.Story{Name: "Trench run"})
+
+		stories, pagination, err := client.Stories(tracker.StoriesQuery{State: tracker.StoryStateStarted})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stories).To(HaveLen(3))
+		Expect(pagination.Total).To(Equal(3))
+
+		labels, pagination, err := client.Labels(tracker.LabelsQuery{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(labels).To(BeEmpty())
+		Expect(pagination.Returned).To(BeZero())
+
+		server.AddLabel(project.ID, tracker.Label{Name: "weapons"})
+		server.AddLabel(project.ID, tracker.Label{Name: "defences"})
+		labels, pagination, err = client.Labels(tracker.LabelsQuery{Limit: 1, Offset: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(labels).To(HaveLen(1))
+		Expect(labels[0].Name).To(Equal("defences"))
+		Expect(pagination).To(Equal(tracker.Pagination{Total: 2, Offset: 1, Limit: 1, Returned: 1}))
+	})
+
+	It("fetches stories by ID", func() {
+		first := server.AddStory(project.ID, tracker.Story{Name: "Superlaser"})
+		server.AddStory(project.ID, tracker.Story{Name: "Tractor beam"})
+		third := server.AddStory(project.ID, tracker.Story{Name: "Trench run"})
+
+		stories, err := client.StoriesByIDs([]int{first.ID, third.ID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stories).To(HaveLen(2))
+		Expect(stories[1].Name).To(Equal("Trench run"))
+	})
+
+	It("searches stories with the filter terms it understands", func() {
+		server.AddStory(project.ID, tracker.Story{
+			Name:   "Superlaser",
+			State:  tracker.StoryStateStarted,
+			Labels: []tracker.Label{{Name: "weapons"}},
+		})
+		server.AddStory(project.ID, tracker.Story{Name: "Tractor beam", State: tracker.StoryStateStarted})
+
+		stories, _, err := client.Stories(tracker.StoriesQuery{
+			Filter: tracker.Filter().State(tracker.StoryStateStarted).Label("weapons").Terms(),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stories).To(HaveLen(1))
+		Expect(stories[0].Name).To(Equal("Superlaser"))
+
+		for _, filter := range []tracker.SearchFilter{
+			tracker.Filter().Owner("vader"),
+			tracker.Filter().Label("death star"),
+			tracker.Filter().State(tracker.StoryStateStarted, tracker.StoryStateFinished),
+		} {
+			_, _, err := client.Stories(tracker.StoriesQuery{Filter: filter.Terms()})
+			Expect(err).To(MatchError(ContainSubstring("unsupported filter term")))
+		}
+	})
+
+	It("moves stories in the backlog", func() {
+		first := server.AddStory(project.ID, tracker.Story{Name: "Superlaser"})
+		second := server.AddStory(project.ID, tracker.Story{Name: "Tractor beam"})
+
+		_, err := client.MoveStory(second.ID, tracker.StoryPosition{BeforeID: first.ID})
+		Expect(err).NotTo(HaveOccurred())
+
+		stories := server.Stories(project.ID)
+		Expect(stories[0].ID).To(Equal(second.ID))
+		Expect(stories[1].ID).To(Equal(first.ID))
+	})
+
+	It("walks stories through the workflow", func() {
+		story := server.AddStory(project.ID, tracker.Story{
+			Name:     "Superlaser",
+			State:    tracker.StoryStateUnstarted,
+			Estimate: tracker.Estimate(2),
+		})
+
+		_, err := client.StartStory(story.ID)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.FinishStory(story.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.DeliverStory(story.ID)).To(Succeed())
+		accepted, err := client.AcceptStory(story.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(accepted.AcceptedAt).NotTo(BeNil())
+	})
+
+	It("refuses writes at a stale project version", func() {
+		story := server.AddStory(project.ID, tracker.Story{Name: "Superlaser"})
+
+		_, version, err := client.StoryWithVersion(story.ID)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.CreateStory(tracker.NewStory{Name: "Tractor beam"})
+		Expect(err).NotTo(HaveOccurred())
+
+		story.Estimate = tracker.Estimate(1)
+		_, err = client.AtVersion(version).UpdateStory(story)
+		Expect(err).To(BeAssignableToTypeOf(tracker.ConflictError{}))
+		Expect(err.(tracker.ConflictError).ProjectVersion).To(Equal(version + 1))
+	})
+
+	It("keeps tasks, comments and blockers", func() {
+		story := server.AddStory(project.ID, tracker.Story{Name: "Superlaser"})
+
+		task, err := client.CreateTask(story.ID, tracker.Task{Description: "Find kyber crystals"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(task.Position).To(Equal(1))
+
+		_, err = client.CreateComment(story.ID, tracker.Comment{Text: "Most impressive"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.CreateBlocker(story.ID, tracker.Blocker{Description: "Galen Erso"})
+		Expect(err).NotTo(HaveOccurred())
+
+		tasks, err := client.StoryTasks(story.ID, tracker.TaskQuery{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(Equal(server.Tasks(project.ID, story.ID)))
+
+		comments, err := client.StoryComments(story.ID, tracker.CommentsQuery{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(HaveLen(1))
+		Expect(comments[0].Text).To(Equal("Most impressive"))
+
+		found, err := client.Story(story.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Blockers).To(HaveLen(1))
+	})
+
+	It("manages memberships", func() {
+		server.AddMembership(project.ID, tracker.ProjectMembership{
+			Person: tracker.Person{Name: "Darth Vader"},
+			Role:   tracker.ProjectRoleOwner,
+		})
+
+		membership, err := client.AddMember(tracker.NewProjectMembership{
+			Email: "tk421@deathstar.mil",
+			Role:  tracker.ProjectRoleViewer,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		membership, err = client.UpdateMemberRole(membership.ID, tracker.ProjectRoleMember)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(membership.Role).To(Equal(tracker.ProjectRoleMember))
+
+		memberships, err := client.ProjectMemberships()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(memberships).To(HaveLen(2))
+
+		Expect(client.RemoveMember(membership.ID)).To(Succeed())
+		memberships, err = client.ProjectMemberships()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(memberships).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,134 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker
+
+import "fmt"
+
+var storyStatesBeforeStart = []StoryState{
+	StoryStateUnscheduled,
+	StoryStateUnstarted,
+	StoryStatePlanned,
+}
+
+// storyWorkflows lists, for each type of story, the states a story may move
+// to from each state it can be in. Stories which have not yet been started
+// can always be shuffled between the icebox and the backlog.
+var storyWorkflows = map[StoryType]map[StoryState][]StoryState{
+	StoryTypeFeature: {
+		StoryStateStarted:   {StoryStateUnstarted, StoryStateFinished},
+		StoryStateFinished:  {StoryStateStarted, StoryStateDelivered},
+		StoryStateDelivered: {StoryStateAccepted, StoryStateRejected},
+		StoryStateRejected:  {StoryStateStarted},
+		StoryStateAccepted:  {},
+	},
+	StoryTypeBug: {
+		StoryStateStarted:   {StoryStateUnstarted, StoryStateFinished},
+		StoryStateFinished:  {StoryStateStarted, StoryStateDelivered},
+		StoryStateDelivered: {StoryStateAccepted, StoryStateRejected},
+		StoryStateRejected:  {StoryStateStarted},
+		StoryStateAccepted:  {},
+	},
+	StoryTypeChore: {
+		StoryStateStarted:  {StoryStateUnstarted, StoryStateAccepted},
+		StoryStateAccepted: {},
+	},
+	StoryTypeRelease: {
+		StoryStateAccepted: {},
+	},
+}
+
+var storyStartStates = map[StoryType]StoryState{
+	StoryTypeFeature: StoryStateStarted,
+	StoryTypeBug:     StoryStateStarted,
+	StoryTypeChore:   StoryStateStarted,
+	StoryTypeRelease: StoryStateAccepted,
+}
+
+type InvalidTransitionError struct {
+	StoryID int
+	Type    StoryType
+	From    StoryState
+	To      StoryState
+	Reason  string
+}
+
+func (e InvalidTransitionError) Error() string {
+	return fmt.Sprintf("cannot move %s %d from %s to %s: %s", e.Type, e.StoryID, e.From, e.To, e.Reason)
+}
+
+// NextStates returns the states that a story of the given type can move to
+// from its current state.
+func NextStates(storyType StoryType, state StoryState) []StoryState {
+	workflow, ok := storyWorkflows[storyType]
+	if !ok {
+		return nil
+	}
+
+	if isBeforeStart(state) {
+		var next []StoryState
+		for _, other := range storyStatesBeforeStart {
+			if other != state {
+				next = append(next, other)
+			}
+		}
+		return append(next, storyStartStates[storyType])
+	}
+
+	return workflow[state]
+}
+
+// ValidateTransition checks that the story can move to the given state
+// according to Tracker's workflow. Unestimated features cannot be started.
+func (s Story) ValidateTransition(state StoryState) error {
+	invalid := func(reason string) error {
+		return InvalidTransitionError{
+			StoryID: s.ID,
+			Type:    s.Type,
+			From:    s.State,
+			To:      state,
+			Reason:  reason,
+		}
+	}
+
+	if _, ok := storyWorkflows[s.Type]; !ok {
+		return invalid("unknown story type")
+	}
+
+	allowed := false
+	for _, next := range NextStates(s.Type, s.State) {
+		if next == state {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return invalid(fmt.Sprintf("%s stories in the %s state can only move to %v", s.Type, s.State, NextStates(s.Type, s.State)))
+	}
+
+	if s.Type == StoryTypeFeature && state == StoryStateStarted && s.Estimate == nil {
+		return invalid("unestimated features cannot be started")
+	}
+
+	return nil
+}
+
+func isBeforeStart(state StoryState) bool {
+	for _, other := range storyStatesBeforeStart {
+		if state == other {
+			return true
+		}
+	}
+	return false
+}
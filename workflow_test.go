@@ -0,0 +1,71 @@
+// Copyright 2016 Christopher Brown. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tracker_test
+
+import (
+	"github.com/deoxxa/go-tracker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Story workflow", func() {
+	story := func(storyType tracker.StoryType, state tracker.StoryState, estimate *int) tracker.Story {
+		return tracker.Story{ID: 560, Type: storyType, State: state, Estimate: estimate}
+	}
+
+	It("lets features move through the whole workflow", func() {
+		Expect(story(tracker.StoryTypeFeature, tracker.StoryStateUnscheduled, tracker.Estimate(1)).ValidateTransition(tracker.StoryStateStarted)).To(Succeed())
+		Expect(story(tracker.StoryTypeFeature, tracker.StoryStateStarted, tracker.Estimate(1)).ValidateTransition(tracker.StoryStateFinished)).To(Succeed())
+		Expect(story(tracker.StoryTypeFeature, tracker.StoryStateFinished, tracker.Estimate(1)).ValidateTransition(tracker.StoryStateDelivered)).To(Succeed())
+		Expect(story(tracker.StoryTypeFeature, tracker.StoryStateDelivered, tracker.Estimate(1)).ValidateTransition(tracker.StoryStateAccepted)).To(Succeed())
+		Expect(story(tracker.StoryTypeFeature, tracker.StoryStateDelivered, tracker.Estimate(1)).ValidateTransition(tracker.StoryStateRejected)).To(Succeed())
+		Expect(story(tracker.StoryTypeFeature, tracker.StoryStateRejected, tracker.Estimate(1)).ValidateTransition(tracker.StoryStateStarted)).To(Succeed())
+	})
+
+	It("does not let stories skip states", func() {
+		err := story(tracker.StoryTypeFeature, tracker.StoryStateUnscheduled, tracker.Estimate(1)).ValidateTransition(tracker.StoryStateAccepted)
+		Expect(err).To(Equal(tracker.InvalidTransitionError{
+			StoryID: 560,
+			Type:    tracker.StoryTypeFeature,
+			From:    tracker.StoryStateUnscheduled,
+			To:      tracker.StoryStateAccepted,
+			Reason:  "feature stories in the unscheduled state can only move to [unstarted planned started]",
+		}))
+	})
+
+	It("does not let unestimated features be started", func() {
+		err := story(tracker.StoryTypeFeature, tracker.StoryStateUnstarted, nil).ValidateTransition(tracker.StoryStateStarted)
+		Expect(err).To(HaveOccurred())
+
+		Expect(story(tracker.StoryTypeBug, tracker.StoryStateUnstarted, nil).ValidateTransition(tracker.StoryStateStarted)).To(Succeed())
+	})
+
+	It("lets features estimated at zero points be started", func() {
+		Expect(story(tracker.StoryTypeFeature, tracker.StoryStateUnstarted, tracker.Estimate(0)).ValidateTransition(tracker.StoryStateStarted)).To(Succeed())
+	})
+
+	It("lets chores be accepted once started", func() {
+		Expect(story(tracker.StoryTypeChore, tracker.StoryStateStarted, nil).ValidateTransition(tracker.StoryStateAccepted)).To(Succeed())
+		Expect(story(tracker.StoryTypeChore, tracker.StoryStateStarted, nil).ValidateTransition(tracker.StoryStateFinished)).NotTo(Succeed())
+	})
+
+	It("lets releases be accepted straight away", func() {
+		Expect(story(tracker.StoryTypeRelease, tracker.StoryStateUnstarted, nil).ValidateTransition(tracker.StoryStateAccepted)).To(Succeed())
+		Expect(story(tracker.StoryTypeRelease, tracker.StoryStateUnstarted, nil).ValidateTransition(tracker.StoryStateStarted)).NotTo(Succeed())
+	})
+
+	It("does not know how to move unknown types of story", func() {
+		Expect(story("epic", tracker.StoryStateUnstarted, nil).ValidateTransition(tracker.StoryStateStarted)).NotTo(Succeed())
+	})
+})